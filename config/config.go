@@ -1,6 +1,11 @@
 package config
 
-import "time"
+import (
+	"sort"
+	"time"
+
+	"github.com/yhkl-dev/NaviCLI/subsonic"
+)
 
 type Config struct {
 	Server ServerConfig `mapstructure:"server"`
@@ -10,22 +15,53 @@ type Config struct {
 }
 
 type ServerConfig struct {
-	URL      string `mapstructure:"url"`
-	Username string `mapstructure:"username"`
-	Password string `mapstructure:"password"`
+	URL      string            `mapstructure:"url"`
+	Username string            `mapstructure:"username"`
+	Password string            `mapstructure:"password"`
+	Auth     string            `mapstructure:"auth"`    // token, password or apikey
+	APIKey   string            `mapstructure:"api_key"` // used by auth = "apikey"
+	Headers  map[string]string `mapstructure:"headers"` // sent with every API call and stream
+
+	MusicFolder string `mapstructure:"music_folder"` // name or ID of the folder to show, "" = all
 }
 
+// Authentication methods of ServerConfig.Auth, passed on to the client as is
+const (
+	AuthToken    = subsonic.AuthToken
+	AuthPassword = subsonic.AuthPassword
+	AuthAPIKey   = subsonic.AuthAPIKey
+)
+
 type UIConfig struct {
-	PageSize         int `mapstructure:"page_size"`
-	FetchSize        int `mapstructure:"fetch_size"`
-	ProgressBarWidth int `mapstructure:"progress_bar_width"`
-	MaxColumnWidth   int `mapstructure:"max_column_width"`
+	PageSize         int  `mapstructure:"page_size"`
+	FetchSize        int  `mapstructure:"fetch_size"`
+	ProgressBarWidth int  `mapstructure:"progress_bar_width"`
+	MaxColumnWidth   int  `mapstructure:"max_column_width"`
+	ShowRating       bool `mapstructure:"show_rating"`
+	MinRating        int  `mapstructure:"min_rating"`
+	AutoExtend       bool `mapstructure:"auto_extend"`
 }
 
 type PlayerConfig struct {
-	HTTPTimeout int `mapstructure:"http_timeout"`
+	HTTPTimeout       int                         `mapstructure:"http_timeout"`
+	BookmarkMinLength int                         `mapstructure:"bookmark_min_length"` // seconds; shorter tracks are never auto-bookmarked, 0 disables
+	Format            string                      `mapstructure:"format"`              // stream format of the default profile, "raw" for the original file
+	MaxBitRate        int                         `mapstructure:"max_bit_rate"`        // kbps cap of the default profile, 0 = no limit
+	Profile           string                      `mapstructure:"profile"`             // profile used at startup, "" = default
+	Profiles          map[string]TranscodeProfile `mapstructure:"profiles"`
 }
 
+// TranscodeProfile selects what the server streams. Format "raw" streams the
+// original file, "" leaves the choice to the server.
+type TranscodeProfile struct {
+	Format     string `mapstructure:"format"`
+	MaxBitRate int    `mapstructure:"max_bit_rate"` // kbps, 0 = no limit
+}
+
+// DefaultProfile names the profile made of the top-level format and
+// max_bit_rate
+const DefaultProfile = "default"
+
 type ClientConfig struct {
 	ID         string `mapstructure:"id"`
 	APIVersion string `mapstructure:"api_version"`
@@ -35,8 +71,33 @@ func (p *PlayerConfig) GetHTTPTimeout() time.Duration {
 	return time.Duration(p.HTTPTimeout) * time.Second
 }
 
+// TranscodeProfile returns the named profile. An unknown name yields the
+// default profile and false.
+func (p *PlayerConfig) TranscodeProfile(name string) (TranscodeProfile, bool) {
+	if profile, ok := p.Profiles[name]; ok && name != DefaultProfile {
+		return profile, true
+	}
+	return TranscodeProfile{Format: p.Format, MaxBitRate: p.MaxBitRate}, name == "" || name == DefaultProfile
+}
+
+// ProfileNames lists the default profile followed by the configured ones in
+// alphabetical order
+func (p *PlayerConfig) ProfileNames() []string {
+	names := make([]string, 0, len(p.Profiles)+1)
+	for name := range p.Profiles {
+		if name != DefaultProfile {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return append([]string{DefaultProfile}, names...)
+}
+
 func DefaultConfig() *Config {
 	return &Config{
+		Server: ServerConfig{
+			Auth: AuthToken,
+		},
 		UI: UIConfig{
 			PageSize:         20,
 			FetchSize:        500,
@@ -44,7 +105,9 @@ func DefaultConfig() *Config {
 			MaxColumnWidth:   40,
 		},
 		Player: PlayerConfig{
-			HTTPTimeout: 30,
+			HTTPTimeout:       30,
+			BookmarkMinLength: 600,
+			Format:            "mp3",
 		},
 		Client: ClientConfig{
 			ID:         "navicli",
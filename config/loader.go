@@ -14,11 +14,18 @@ func Load() (*Config, error) {
 	viper.AddConfigPath(".")
 
 	defaults := DefaultConfig()
+	viper.SetDefault("server.auth", defaults.Server.Auth)
 	viper.SetDefault("ui.page_size", defaults.UI.PageSize)
 	viper.SetDefault("ui.fetch_size", defaults.UI.FetchSize)
 	viper.SetDefault("ui.progress_bar_width", defaults.UI.ProgressBarWidth)
 	viper.SetDefault("ui.max_column_width", defaults.UI.MaxColumnWidth)
+	viper.SetDefault("ui.show_rating", defaults.UI.ShowRating)
+	viper.SetDefault("ui.min_rating", defaults.UI.MinRating)
+	viper.SetDefault("ui.auto_extend", defaults.UI.AutoExtend)
 	viper.SetDefault("player.http_timeout", defaults.Player.HTTPTimeout)
+	viper.SetDefault("player.bookmark_min_length", defaults.Player.BookmarkMinLength)
+	viper.SetDefault("player.format", defaults.Player.Format)
+	viper.SetDefault("player.max_bit_rate", defaults.Player.MaxBitRate)
 	viper.SetDefault("client.id", defaults.Client.ID)
 	viper.SetDefault("client.api_version", defaults.Client.APIVersion)
 
@@ -26,10 +33,14 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	required := []string{
-		"server.url",
-		"server.username",
-		"server.password",
+	required := []string{"server.url"}
+	switch auth := viper.GetString("server.auth"); auth {
+	case AuthToken, AuthPassword:
+		required = append(required, "server.username", "server.password")
+	case AuthAPIKey:
+		required = append(required, "server.api_key")
+	default:
+		return nil, fmt.Errorf("invalid server.auth %q, use %q, %q or %q", auth, AuthToken, AuthPassword, AuthAPIKey)
 	}
 	for _, key := range required {
 		if !viper.IsSet(key) {
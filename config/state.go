@@ -0,0 +1,73 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// State holds UI choices remembered between launches. Unlike Config it is
+// written by NaviCLI itself, to state.json next to the config file.
+type State struct {
+	Source   string `json:"source,omitempty"` // song source name, e.g. "Newest"
+	Genre    string `json:"genre,omitempty"`
+	FromYear int    `json:"from_year,omitempty"`
+	ToYear   int    `json:"to_year,omitempty"`
+	Profile  string `json:"profile,omitempty"` // transcoding profile picked with t
+
+	MusicFolder string `json:"music_folder,omitempty"` // ID picked with F, or AllMusicFolders
+}
+
+// AllMusicFolders is the State.MusicFolder of a user who picked all folders,
+// which overrides the config's music_folder
+const AllMusicFolders = "all"
+
+func StatePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "navicli", "state.json")
+}
+
+// LoadState reads the saved state. A missing file yields an empty state.
+func LoadState() (*State, error) {
+	state := &State{}
+	path := StatePath()
+	if path == "" {
+		return state, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("read state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return &State{}, fmt.Errorf("parse state: %w", err)
+	}
+	return state, nil
+}
+
+func (s *State) Save() error {
+	path := StatePath()
+	if path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create state dir: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode state: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write state: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
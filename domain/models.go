@@ -12,6 +12,9 @@ type Song struct {
 	Artist       string
 	Duration     int // in seconds
 	Track        int
+	DiscNumber   int
+	Year         int
+	Genre        string
 	CoverArt     string
 	Size         int64
 	ContentType  string
@@ -26,8 +29,209 @@ type Song struct {
 	Played       *time.Time
 	ChannelCount int
 	SampleRate   int
+	Starred      *time.Time
+	UserRating   int    // 0 = unrated, 1-5
+	StreamURL    string // set for internet radio, which is played directly and has no duration
+	IsPodcast    bool   // podcast episodes are always bookmarked when stopped mid-way
 }
 
+type Artist struct {
+	ID         string
+	Name       string
+	AlbumCount int
+	CoverArt   string
+	Starred    *time.Time
+	Albums     []Album // empty when loaded through GetArtistIndexes
+}
+
+// ArtistIndex groups artists under an index letter
+type ArtistIndex struct {
+	Name    string
+	Artists []Artist
+}
+
+type Album struct {
+	ID        string
+	Name      string
+	Artist    string
+	ArtistID  string
+	Year      int
+	Genre     string
+	SongCount int
+	Duration  int // in seconds
+	CoverArt  string
+	Starred   *time.Time
+	Songs     []Song // in disc/track order; empty unless loaded through GetAlbum
+}
+
+// SearchResult groups the hits of a search by kind
+type SearchResult struct {
+	Artists []Artist
+	Albums  []Album
+	Songs   []Song
+}
+
+// Directory is a folder of the server's file tree, as it is laid out on disk
+type Directory struct {
+	ID     string
+	Parent string // empty for the root
+	Name   string
+	Dirs   []DirectoryEntry
+	Songs  []Song
+}
+
+// DirectoryEntry is a subdirectory, listed without its contents
+type DirectoryEntry struct {
+	ID   string
+	Name string
+}
+
+// NowPlaying is a song another listener, or this one, is playing
+type NowPlaying struct {
+	Song       Song
+	Username   string
+	PlayerName string // client name, empty if the player is unnamed
+	MinutesAgo int
+}
+
+// ScanStatus reports a library scan on the server
+type ScanStatus struct {
+	Scanning bool
+	Count    int // items scanned so far
+}
+
+// MusicFolder is a top-level media folder, such as a separate audiobook
+// library
+type MusicFolder struct {
+	ID   string
+	Name string
+}
+
+type Genre struct {
+	Name       string
+	SongCount  int
+	AlbumCount int
+}
+
+// Lyrics holds a song's lyrics. Lines of synced lyrics carry the time they
+// start at, with the server's offset already applied.
+type Lyrics struct {
+	Synced bool
+	Lines  []LyricLine
+}
+
+type LyricLine struct {
+	Start time.Duration
+	Text  string
+}
+
+type Playlist struct {
+	ID        string
+	Name      string
+	Comment   string
+	Owner     string
+	Public    bool
+	SongCount int
+	Duration  int // in seconds
+	Changed   time.Time
+	Songs     []Song // empty when loaded through GetPlaylists
+}
+
+type PodcastChannel struct {
+	ID          string
+	URL         string
+	Title       string
+	Description string
+	Status      string
+	Error       string
+	Episodes    []PodcastEpisode // empty when loaded through GetPodcasts
+}
+
+// PodcastEpisode is one episode of a channel. Song is what gets played; its ID
+// is the episode's stream ID, which is empty until the server has downloaded
+// the episode.
+type PodcastEpisode struct {
+	ID          string
+	ChannelID   string
+	Title       string
+	Description string
+	Status      string // new, downloading, completed, error, deleted or skipped
+	PublishDate time.Time
+	Song        Song
+}
+
+// Downloaded reports whether the episode can be streamed
+func (e PodcastEpisode) Downloaded() bool {
+	return e.Status == "completed" && e.Song.ID != ""
+}
+
+// Played reports whether the episode has been listened to
+func (e PodcastEpisode) Played() bool {
+	return e.Song.PlayCount > 0
+}
+
+// RadioStation is an internet radio stream stored on the server
+type RadioStation struct {
+	ID          string
+	Name        string
+	StreamURL   string
+	HomePageURL string
+}
+
+// PlayQueue is a queue saved on the server so playback can move between
+// clients
+type PlayQueue struct {
+	Songs     []Song
+	Current   string        // ID of the song that was playing
+	Position  time.Duration // playback position within Current
+	Changed   time.Time
+	ChangedBy string // client that saved the queue
+}
+
+// Bookmark is a saved position within a song, used to resume long tracks
+type Bookmark struct {
+	Song     Song
+	Position time.Duration
+	Comment  string
+	Changed  time.Time
+}
+
+// ServerInfo describes the connected server as found at connect time
+type ServerInfo struct {
+	Type          string // server software, e.g. "navidrome"; empty unless OpenSubsonic
+	ServerVersion string
+	APIVersion    string // Subsonic API version the server implements
+	OpenSubsonic  bool
+	Extensions    map[string][]int // supported OpenSubsonic extensions and their versions
+}
+
+// OpenSubsonic extensions that turn client features on
+const (
+	ExtSongLyrics      = "songLyrics"           // structured, synced lyrics
+	ExtAPIKeyAuth      = "apiKeyAuthentication" // apiKey parameter instead of a password
+	ExtTranscodeOffset = "transcodeOffset"      // transcoded streams can start at an offset
+)
+
+// Supports reports whether the server implements an OpenSubsonic extension.
+// A nil ServerInfo supports nothing.
+func (s *ServerInfo) Supports(extension string) bool {
+	if s == nil {
+		return false
+	}
+	_, ok := s.Extensions[extension]
+	return ok
+}
+
+// ItemKind identifies which kind of library item an annotation such as a
+// star applies to.
+type ItemKind int
+
+const (
+	ItemSong ItemKind = iota
+	ItemAlbum
+	ItemArtist
+)
+
 type QueueItem struct {
 	ID       string
 	URI      string
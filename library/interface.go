@@ -1,12 +1,92 @@
 package library
 
-import "github.com/yhkl-dev/NaviCLI/domain"
+import (
+	"context"
+	"time"
+
+	"github.com/yhkl-dev/NaviCLI/domain"
+)
+
+// AlbumListOptions narrows GetAlbumSongs. FromYear/ToYear apply to the byYear
+// list type and Genre to byGenre.
+type AlbumListOptions struct {
+	FromYear int
+	ToYear   int
+	Genre    string
+	Limit    int // stop once this many songs are loaded, 0 = whole list
+}
+
+// SearchOptions sets the page size and offset of each kind of search hit.
+// A count of 0 leaves that kind out of the result.
+type SearchOptions struct {
+	ArtistCount  int
+	ArtistOffset int
+	AlbumCount   int
+	AlbumOffset  int
+	SongCount    int
+	SongOffset   int
+}
+
+// StreamOptions selects the transcoding of a stream. Format "raw" streams the
+// original file.
+type StreamOptions struct {
+	Format     string
+	MaxBitRate int // kbps, 0 = no limit
+	TimeOffset int // seconds to skip; only honoured when transcoding on servers with the transcodeOffset extension
+}
 
 type Library interface {
-	GetRandomSongs(count int) ([]domain.Song, error)
-	GetAlbumSongs(albumType string) ([]domain.Song, error)
-	SearchSongs(query string, limit int) ([]domain.Song, error)
-	GetPlayURL(songID string) string
+	GetRandomSongs(ctx context.Context, count int) ([]domain.Song, error)
+	GetAlbumSongs(ctx context.Context, albumType string, opts AlbumListOptions) ([]domain.Song, error)
+	Search(ctx context.Context, query string, opts SearchOptions) (*domain.SearchResult, error)
+	GetPlayURL(songID string, opts StreamOptions) string
 	GetCoverArtURL(coverArtID string) string
-	Ping() error
+	Connect(ctx context.Context) (*domain.ServerInfo, error)
+	ServerInfo() *domain.ServerInfo // as found by Connect, nil before
+	Ping(ctx context.Context) error
+	OnAvailabilityChange(fn func(available bool)) // called when the server stops or starts answering
+	Scrobble(ctx context.Context, songID string, playedAt time.Time, submission bool) error
+	SetStarred(ctx context.Context, kind domain.ItemKind, id string, starred bool) error
+	GetStarredSongs(ctx context.Context) ([]domain.Song, error)
+	SetRating(ctx context.Context, id string, rating int) error
+	GetPlaylists(ctx context.Context) ([]domain.Playlist, error)
+	GetPlaylist(ctx context.Context, id string) (*domain.Playlist, error)
+	CreatePlaylist(ctx context.Context, name string, songIDs []string) (*domain.Playlist, error)
+	RenamePlaylist(ctx context.Context, id, name string) error
+	AddToPlaylist(ctx context.Context, id string, songIDs []string) error
+	ReplacePlaylistSongs(ctx context.Context, id string, songIDs []string) error
+	DeletePlaylist(ctx context.Context, id string) error
+	GetArtistIndexes(ctx context.Context) ([]domain.ArtistIndex, error)
+	GetArtist(ctx context.Context, id string) (*domain.Artist, error)
+	GetAlbum(ctx context.Context, id string) (*domain.Album, error)
+	GetRootDirectory(ctx context.Context) (*domain.Directory, error)
+	GetDirectory(ctx context.Context, id string) (*domain.Directory, error)
+	GetDirectorySongs(ctx context.Context, id string, limit int) ([]domain.Song, bool, error) // recursive, 0 = no limit; reports truncation
+	GetNowPlaying(ctx context.Context) ([]domain.NowPlaying, error)
+	StartScan(ctx context.Context) (*domain.ScanStatus, error)
+	GetScanStatus(ctx context.Context) (*domain.ScanStatus, error)
+	GetMusicFolders(ctx context.Context) ([]domain.MusicFolder, error)
+	SetMusicFolder(id string) // limits lists, searches and artists to one folder, "" = all
+	MusicFolder() string
+	GetGenres(ctx context.Context) ([]domain.Genre, error)
+	GetSongsByGenre(ctx context.Context, genre string, limit int) ([]domain.Song, error)
+	GetLyrics(ctx context.Context, song domain.Song) (*domain.Lyrics, error)
+	GetSimilarSongs(ctx context.Context, id string, limit int) ([]domain.Song, error)
+	GetTopSongs(ctx context.Context, artist string, limit int) ([]domain.Song, error)
+	SavePlayQueue(ctx context.Context, songIDs []string, current string, position time.Duration) error
+	GetPlayQueue(ctx context.Context) (*domain.PlayQueue, error)
+	GetBookmarks(ctx context.Context) ([]domain.Bookmark, error)
+	CreateBookmark(ctx context.Context, songID string, position time.Duration, comment string) error
+	DeleteBookmark(ctx context.Context, songID string) error
+	GetRadioStations(ctx context.Context) ([]domain.RadioStation, error)
+	CreateRadioStation(ctx context.Context, station domain.RadioStation) error
+	UpdateRadioStation(ctx context.Context, station domain.RadioStation) error
+	DeleteRadioStation(ctx context.Context, id string) error
+	GetPodcasts(ctx context.Context) ([]domain.PodcastChannel, error)
+	GetPodcastChannel(ctx context.Context, id string) (*domain.PodcastChannel, error)
+	GetNewestPodcasts(ctx context.Context, limit int) ([]domain.PodcastEpisode, error)
+	RefreshPodcasts(ctx context.Context) error
+	CreatePodcastChannel(ctx context.Context, feedURL string) error
+	DeletePodcastChannel(ctx context.Context, id string) error
+	DownloadPodcastEpisode(ctx context.Context, id string) error
 }
@@ -1,7 +1,12 @@
 package library
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/yhkl-dev/NaviCLI/domain"
@@ -10,6 +15,8 @@ import (
 
 type SubsonicLibrary struct {
 	client *subsonic.Client
+	mu     sync.RWMutex
+	server *domain.ServerInfo // nil until Connect succeeds
 }
 
 func NewSubsonicLibrary(client *subsonic.Client) *SubsonicLibrary {
@@ -18,21 +25,27 @@ func NewSubsonicLibrary(client *subsonic.Client) *SubsonicLibrary {
 	}
 }
 
-func (s *SubsonicLibrary) GetRandomSongs(count int) ([]domain.Song, error) {
-	songs, err := s.client.GetRandomSongs(count)
+func (s *SubsonicLibrary) GetRandomSongs(ctx context.Context, count int) ([]domain.Song, error) {
+	songs, err := s.client.GetRandomSongs(ctx, count)
 	if err != nil {
 		return nil, err
 	}
 	return convertToDomainSongs(songs), nil
 }
 
-func (s *SubsonicLibrary) GetAlbumSongs(albumType string) ([]domain.Song, error) {
+func (s *SubsonicLibrary) GetAlbumSongs(ctx context.Context, albumType string, opts AlbumListOptions) ([]domain.Song, error) {
 	const batchSize = 50 // albums per paginated fetch
 
+	filter := subsonic.AlbumListFilter{
+		FromYear: opts.FromYear,
+		ToYear:   opts.ToYear,
+		Genre:    opts.Genre,
+	}
+
 	var allSongs []domain.Song
 	offset := 0
 	for {
-		albums, err := s.client.GetAlbumList2(albumType, batchSize, offset)
+		albums, err := s.client.GetAlbumList2(ctx, albumType, batchSize, offset, filter)
 		if err != nil {
 			return nil, err
 		}
@@ -41,12 +54,18 @@ func (s *SubsonicLibrary) GetAlbumSongs(albumType string) ([]domain.Song, error)
 		}
 
 		for _, album := range albums {
-			songs, err := s.client.GetAlbum(album.ID)
+			full, err := s.client.GetAlbum(ctx, album.ID)
 			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
 				log.Printf("skip album %s: %v", album.Name, err)
 				continue
 			}
-			allSongs = append(allSongs, convertToDomainSongs(songs)...)
+			allSongs = append(allSongs, convertToDomainSongs(full.Songs)...)
+			if opts.Limit > 0 && len(allSongs) >= opts.Limit {
+				return allSongs[:opts.Limit], nil
+			}
 		}
 
 		if len(albums) < batchSize {
@@ -57,24 +76,671 @@ func (s *SubsonicLibrary) GetAlbumSongs(albumType string) ([]domain.Song, error)
 	return allSongs, nil
 }
 
-func (s *SubsonicLibrary) SearchSongs(query string, limit int) ([]domain.Song, error) {
-	songs, err := s.client.SearchSongs(query)
+func (s *SubsonicLibrary) Search(ctx context.Context, query string, opts SearchOptions) (*domain.SearchResult, error) {
+	result, err := s.client.Search3(ctx, query, subsonic.SearchOptions{
+		ArtistCount:  opts.ArtistCount,
+		ArtistOffset: opts.ArtistOffset,
+		AlbumCount:   opts.AlbumCount,
+		AlbumOffset:  opts.AlbumOffset,
+		SongCount:    opts.SongCount,
+		SongOffset:   opts.SongOffset,
+	})
 	if err != nil {
 		return nil, err
 	}
-	return convertToDomainSongs(songs), nil
+
+	artists := make([]domain.Artist, len(result.Artists))
+	for i, artist := range result.Artists {
+		artists[i] = convertToDomainArtist(artist)
+	}
+	albums := make([]domain.Album, len(result.Albums))
+	for i, album := range result.Albums {
+		albums[i] = convertToDomainAlbum(album)
+	}
+	return &domain.SearchResult{
+		Artists: artists,
+		Albums:  albums,
+		Songs:   convertToDomainSongs(result.Songs),
+	}, nil
 }
 
-func (s *SubsonicLibrary) GetPlayURL(songID string) string {
-	return s.client.GetPlayURL(songID)
+func (s *SubsonicLibrary) GetPlayURL(songID string, opts StreamOptions) string {
+	return s.client.GetPlayURL(songID, subsonic.StreamOptions{
+		Format:     opts.Format,
+		MaxBitRate: opts.MaxBitRate,
+		TimeOffset: opts.TimeOffset,
+	})
 }
 
 func (s *SubsonicLibrary) GetCoverArtURL(coverArtID string) string {
 	return s.client.GetCoverArtURL(coverArtID)
 }
 
-func (s *SubsonicLibrary) Ping() error {
-	return s.client.GetServerInfo()
+// Connect pings the server and records what it is and which OpenSubsonic
+// extensions it supports. A failed extension lookup is logged and leaves the
+// extension list empty.
+func (s *SubsonicLibrary) Connect(ctx context.Context) (*domain.ServerInfo, error) {
+	ping, err := s.client.GetServerInfo(ctx)
+	if err != nil {
+		if s.client.Auth == subsonic.AuthAPIKey && !s.supportsAPIKey(ctx) {
+			return nil, fmt.Errorf("the server does not support API key authentication: %w", err)
+		}
+		return nil, err
+	}
+	info := &domain.ServerInfo{
+		Type:          ping.Type,
+		ServerVersion: ping.ServerVersion,
+		APIVersion:    ping.Version,
+		OpenSubsonic:  ping.OpenSubsonic,
+		Extensions:    make(map[string][]int),
+	}
+	if ping.OpenSubsonic {
+		extensions, err := s.client.GetOpenSubsonicExtensions(ctx)
+		if err != nil {
+			log.Printf("Failed to load OpenSubsonic extensions: %v", err)
+		}
+		for _, ext := range extensions {
+			info.Extensions[ext.Name] = ext.Versions
+		}
+	}
+
+	s.mu.Lock()
+	s.server = info
+	s.mu.Unlock()
+	return info, nil
+}
+
+// supportsAPIKey reports whether the server lists the API key extension.
+// getOpenSubsonicExtensions needs no authentication, so this works while the
+// key is being rejected.
+func (s *SubsonicLibrary) supportsAPIKey(ctx context.Context) bool {
+	extensions, err := s.client.GetOpenSubsonicExtensions(ctx)
+	if err != nil {
+		return false
+	}
+	for _, ext := range extensions {
+		if ext.Name == domain.ExtAPIKeyAuth {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SubsonicLibrary) ServerInfo() *domain.ServerInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.server
+}
+
+func (s *SubsonicLibrary) Ping(ctx context.Context) error {
+	_, err := s.client.GetServerInfo(ctx)
+	return err
+}
+
+func (s *SubsonicLibrary) OnAvailabilityChange(fn func(available bool)) {
+	s.client.OnAvailabilityChange(fn)
+}
+
+func (s *SubsonicLibrary) Scrobble(ctx context.Context, songID string, playedAt time.Time, submission bool) error {
+	return s.client.Scrobble(ctx, songID, playedAt, submission)
+}
+
+func (s *SubsonicLibrary) SetStarred(ctx context.Context, kind domain.ItemKind, id string, starred bool) error {
+	param := subsonic.StarSong
+	switch kind {
+	case domain.ItemAlbum:
+		param = subsonic.StarAlbum
+	case domain.ItemArtist:
+		param = subsonic.StarArtist
+	}
+	if starred {
+		return s.client.Star(ctx, param, id)
+	}
+	return s.client.Unstar(ctx, param, id)
+}
+
+func (s *SubsonicLibrary) GetStarredSongs(ctx context.Context) ([]domain.Song, error) {
+	starred, err := s.client.GetStarred2(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return convertToDomainSongs(starred.Songs), nil
+}
+
+func (s *SubsonicLibrary) SetRating(ctx context.Context, id string, rating int) error {
+	return s.client.SetRating(ctx, id, rating)
+}
+
+func (s *SubsonicLibrary) GetPlaylists(ctx context.Context) ([]domain.Playlist, error) {
+	playlists, err := s.client.GetPlaylists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.Playlist, len(playlists))
+	for i, p := range playlists {
+		result[i] = convertToDomainPlaylist(p)
+	}
+	return result, nil
+}
+
+func (s *SubsonicLibrary) GetPlaylist(ctx context.Context, id string) (*domain.Playlist, error) {
+	playlist, err := s.client.GetPlaylist(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if playlist == nil {
+		return nil, fmt.Errorf("playlist %s not found", id)
+	}
+	result := convertToDomainPlaylist(*playlist)
+	return &result, nil
+}
+
+func (s *SubsonicLibrary) CreatePlaylist(ctx context.Context, name string, songIDs []string) (*domain.Playlist, error) {
+	playlist, err := s.client.CreatePlaylist(ctx, name, songIDs)
+	if err != nil {
+		return nil, err
+	}
+	if playlist == nil {
+		// Pre-1.14 servers don't echo the new playlist back
+		return &domain.Playlist{Name: name, SongCount: len(songIDs)}, nil
+	}
+	result := convertToDomainPlaylist(*playlist)
+	return &result, nil
+}
+
+func (s *SubsonicLibrary) RenamePlaylist(ctx context.Context, id, name string) error {
+	return s.client.UpdatePlaylist(ctx, id, subsonic.PlaylistUpdate{Name: name})
+}
+
+func (s *SubsonicLibrary) AddToPlaylist(ctx context.Context, id string, songIDs []string) error {
+	return s.client.UpdatePlaylist(ctx, id, subsonic.PlaylistUpdate{SongIDsToAdd: songIDs})
+}
+
+func (s *SubsonicLibrary) ReplacePlaylistSongs(ctx context.Context, id string, songIDs []string) error {
+	return s.client.ReplacePlaylistSongs(ctx, id, songIDs)
+}
+
+func (s *SubsonicLibrary) DeletePlaylist(ctx context.Context, id string) error {
+	return s.client.DeletePlaylist(ctx, id)
+}
+
+func (s *SubsonicLibrary) GetArtistIndexes(ctx context.Context) ([]domain.ArtistIndex, error) {
+	indexes, err := s.client.GetArtists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.ArtistIndex, len(indexes))
+	for i, index := range indexes {
+		artists := make([]domain.Artist, len(index.Artists))
+		for j, artist := range index.Artists {
+			artists[j] = convertToDomainArtist(artist)
+		}
+		result[i] = domain.ArtistIndex{Name: index.Name, Artists: artists}
+	}
+	return result, nil
+}
+
+func (s *SubsonicLibrary) GetArtist(ctx context.Context, id string) (*domain.Artist, error) {
+	artist, err := s.client.GetArtist(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	result := convertToDomainArtist(*artist)
+	return &result, nil
+}
+
+func (s *SubsonicLibrary) GetAlbum(ctx context.Context, id string) (*domain.Album, error) {
+	album, err := s.client.GetAlbum(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	result := convertToDomainAlbum(*album)
+	sort.SliceStable(result.Songs, func(i, j int) bool {
+		a, b := result.Songs[i], result.Songs[j]
+		if a.DiscNumber != b.DiscNumber {
+			return a.DiscNumber < b.DiscNumber
+		}
+		return a.Track < b.Track
+	})
+	return &result, nil
+}
+
+// GetRootDirectory returns the top of the file tree, with the top-level
+// directories of every index letter in one list
+func (s *SubsonicLibrary) GetRootDirectory(ctx context.Context) (*domain.Directory, error) {
+	indexes, err := s.client.GetIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	root := &domain.Directory{}
+	for _, index := range indexes.Index {
+		for _, entry := range index.Entries {
+			root.Dirs = append(root.Dirs, domain.DirectoryEntry{ID: entry.ID, Name: entry.Name})
+		}
+	}
+	root.Songs = directorySongs(indexes.Children)
+	return root, nil
+}
+
+func (s *SubsonicLibrary) GetDirectory(ctx context.Context, id string) (*domain.Directory, error) {
+	dir, err := s.client.GetMusicDirectory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	result := &domain.Directory{ID: dir.ID, Parent: dir.Parent, Name: dir.Name}
+	for _, child := range dir.Children {
+		if child.IsDir {
+			result.Dirs = append(result.Dirs, domain.DirectoryEntry{ID: child.ID, Name: child.Title})
+		}
+	}
+	result.Songs = directorySongs(dir.Children)
+	return result, nil
+}
+
+// GetDirectorySongs walks the tree under a directory depth first and returns
+// its songs in the order the server lists them, stopping once limit songs are
+// loaded. truncated reports whether songs were left out because of the limit.
+func (s *SubsonicLibrary) GetDirectorySongs(ctx context.Context, id string, limit int) (songs []domain.Song, truncated bool, err error) {
+	var walk func(id string) error
+	walk = func(id string) error {
+		dir, err := s.client.GetMusicDirectory(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, child := range dir.Children {
+			if truncated {
+				return nil
+			}
+			if child.IsDir {
+				if err := walk(child.ID); err != nil {
+					return err
+				}
+			} else if !child.IsVideo {
+				if limit > 0 && len(songs) >= limit {
+					truncated = true
+					return nil
+				}
+				songs = append(songs, convertToDomainSong(child))
+			}
+		}
+		return nil
+	}
+	if err := walk(id); err != nil {
+		return nil, false, err
+	}
+	return songs, truncated, nil
+}
+
+// directorySongs converts the files of a directory listing, leaving out
+// subdirectories and videos
+func directorySongs(children []subsonic.Song) []domain.Song {
+	var songs []domain.Song
+	for _, child := range children {
+		if !child.IsDir && !child.IsVideo {
+			songs = append(songs, convertToDomainSong(child))
+		}
+	}
+	return songs
+}
+
+func (s *SubsonicLibrary) GetNowPlaying(ctx context.Context) ([]domain.NowPlaying, error) {
+	entries, err := s.client.GetNowPlaying(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.NowPlaying, len(entries))
+	for i, e := range entries {
+		result[i] = domain.NowPlaying{
+			Song:       convertToDomainSong(e.Song),
+			Username:   e.Username,
+			PlayerName: e.PlayerName,
+			MinutesAgo: e.MinutesAgo,
+		}
+	}
+	return result, nil
+}
+
+func (s *SubsonicLibrary) StartScan(ctx context.Context) (*domain.ScanStatus, error) {
+	status, err := s.client.StartScan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ScanStatus{Scanning: status.Scanning, Count: status.Count}, nil
+}
+
+func (s *SubsonicLibrary) GetScanStatus(ctx context.Context) (*domain.ScanStatus, error) {
+	status, err := s.client.GetScanStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ScanStatus{Scanning: status.Scanning, Count: status.Count}, nil
+}
+
+func (s *SubsonicLibrary) GetMusicFolders(ctx context.Context) ([]domain.MusicFolder, error) {
+	folders, err := s.client.GetMusicFolders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.MusicFolder, len(folders))
+	for i, f := range folders {
+		result[i] = domain.MusicFolder{ID: f.ID, Name: f.Name}
+	}
+	return result, nil
+}
+
+func (s *SubsonicLibrary) SetMusicFolder(id string) {
+	s.client.SetMusicFolder(id)
+}
+
+func (s *SubsonicLibrary) MusicFolder() string {
+	return s.client.MusicFolder()
+}
+
+func (s *SubsonicLibrary) GetGenres(ctx context.Context) ([]domain.Genre, error) {
+	genres, err := s.client.GetGenres(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.Genre, len(genres))
+	for i, g := range genres {
+		result[i] = domain.Genre{Name: g.Name, SongCount: g.SongCount, AlbumCount: g.AlbumCount}
+	}
+	return result, nil
+}
+
+// GetSongsByGenre pages through a genre until limit songs are loaded or the
+// genre is exhausted.
+func (s *SubsonicLibrary) GetSongsByGenre(ctx context.Context, genre string, limit int) ([]domain.Song, error) {
+	const batchSize = 500 // server-side maximum per request
+
+	var allSongs []domain.Song
+	for offset := 0; offset < limit; offset += batchSize {
+		count := min(batchSize, limit-offset)
+		songs, err := s.client.GetSongsByGenre(ctx, genre, count, offset)
+		if err != nil {
+			return nil, err
+		}
+		allSongs = append(allSongs, convertToDomainSongs(songs)...)
+		if len(songs) < count {
+			break
+		}
+	}
+	return allSongs, nil
+}
+
+// GetLyrics prefers synced structured lyrics, then unsynced structured ones,
+// then the legacy artist/title lookup. Structured lyrics are skipped on
+// servers known not to support the songLyrics extension. It returns nil when
+// the server has no lyrics for the song.
+func (s *SubsonicLibrary) GetLyrics(ctx context.Context, song domain.Song) (*domain.Lyrics, error) {
+	if info := s.ServerInfo(); info == nil || info.Supports(domain.ExtSongLyrics) {
+		if lyrics := s.structuredLyrics(ctx, song.ID); lyrics != nil {
+			return lyrics, nil
+		}
+	}
+
+	legacy, err := s.client.GetLyrics(ctx, song.Artist, song.Title)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimSpace(strings.ReplaceAll(legacy.Value, "\r\n", "\n"))
+	if text == "" {
+		return nil, nil
+	}
+	lyrics := &domain.Lyrics{}
+	for _, line := range strings.Split(text, "\n") {
+		lyrics.Lines = append(lyrics.Lines, domain.LyricLine{Text: line})
+	}
+	return lyrics, nil
+}
+
+// structuredLyrics returns the synced structured lyrics of a song, else its
+// first unsynced ones, or nil if it has none
+func (s *SubsonicLibrary) structuredLyrics(ctx context.Context, songID string) *domain.Lyrics {
+	structured, err := s.client.GetLyricsBySongID(ctx, songID)
+	if err != nil || len(structured) == 0 {
+		return nil
+	}
+	best := structured[0]
+	for _, l := range structured {
+		if l.Synced && len(l.Lines) > 0 {
+			best = l
+			break
+		}
+	}
+	if len(best.Lines) == 0 {
+		return nil
+	}
+	lyrics := &domain.Lyrics{Synced: best.Synced}
+	for _, line := range best.Lines {
+		lyrics.Lines = append(lyrics.Lines, domain.LyricLine{
+			Start: time.Duration(line.Start-best.Offset) * time.Millisecond,
+			Text:  line.Value,
+		})
+	}
+	return lyrics
+}
+
+// GetSimilarSongs returns songs similar to a song or artist ID. The seed song
+// itself is not included.
+func (s *SubsonicLibrary) GetSimilarSongs(ctx context.Context, id string, limit int) ([]domain.Song, error) {
+	songs, err := s.client.GetSimilarSongs2(ctx, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return convertToDomainSongs(songs), nil
+}
+
+func (s *SubsonicLibrary) GetTopSongs(ctx context.Context, artist string, limit int) ([]domain.Song, error) {
+	songs, err := s.client.GetTopSongs(ctx, artist, limit)
+	if err != nil {
+		return nil, err
+	}
+	return convertToDomainSongs(songs), nil
+}
+
+func (s *SubsonicLibrary) SavePlayQueue(ctx context.Context, songIDs []string, current string, position time.Duration) error {
+	return s.client.SavePlayQueue(ctx, songIDs, current, position.Milliseconds())
+}
+
+func (s *SubsonicLibrary) GetPlayQueue(ctx context.Context) (*domain.PlayQueue, error) {
+	queue, err := s.client.GetPlayQueue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PlayQueue{
+		Songs:     convertToDomainSongs(queue.Entries),
+		Current:   queue.Current,
+		Position:  time.Duration(queue.Position) * time.Millisecond,
+		Changed:   queue.Changed,
+		ChangedBy: queue.ChangedBy,
+	}, nil
+}
+
+func (s *SubsonicLibrary) GetBookmarks(ctx context.Context) ([]domain.Bookmark, error) {
+	bookmarks, err := s.client.GetBookmarks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.Bookmark, len(bookmarks))
+	for i, b := range bookmarks {
+		result[i] = domain.Bookmark{
+			Song:     convertToDomainSong(b.Entry),
+			Position: time.Duration(b.Position) * time.Millisecond,
+			Comment:  b.Comment,
+			Changed:  b.Changed,
+		}
+	}
+	return result, nil
+}
+
+func (s *SubsonicLibrary) CreateBookmark(ctx context.Context, songID string, position time.Duration, comment string) error {
+	return s.client.CreateBookmark(ctx, songID, position.Milliseconds(), comment)
+}
+
+func (s *SubsonicLibrary) DeleteBookmark(ctx context.Context, songID string) error {
+	return s.client.DeleteBookmark(ctx, songID)
+}
+
+func (s *SubsonicLibrary) GetRadioStations(ctx context.Context) ([]domain.RadioStation, error) {
+	stations, err := s.client.GetInternetRadioStations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.RadioStation, len(stations))
+	for i, st := range stations {
+		result[i] = domain.RadioStation{
+			ID:          st.ID,
+			Name:        st.Name,
+			StreamURL:   st.StreamURL,
+			HomePageURL: st.HomePageURL,
+		}
+	}
+	return result, nil
+}
+
+func (s *SubsonicLibrary) CreateRadioStation(ctx context.Context, station domain.RadioStation) error {
+	return s.client.CreateInternetRadioStation(ctx, station.StreamURL, station.Name, station.HomePageURL)
+}
+
+func (s *SubsonicLibrary) UpdateRadioStation(ctx context.Context, station domain.RadioStation) error {
+	return s.client.UpdateInternetRadioStation(ctx, station.ID, station.StreamURL, station.Name, station.HomePageURL)
+}
+
+func (s *SubsonicLibrary) DeleteRadioStation(ctx context.Context, id string) error {
+	return s.client.DeleteInternetRadioStation(ctx, id)
+}
+
+func (s *SubsonicLibrary) GetPodcasts(ctx context.Context) ([]domain.PodcastChannel, error) {
+	channels, err := s.client.GetPodcasts(ctx, "", false)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.PodcastChannel, len(channels))
+	for i, ch := range channels {
+		result[i] = convertToDomainChannel(ch)
+	}
+	return result, nil
+}
+
+// GetPodcastChannel returns a channel with its episodes, newest first
+func (s *SubsonicLibrary) GetPodcastChannel(ctx context.Context, id string) (*domain.PodcastChannel, error) {
+	channels, err := s.client.GetPodcasts(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("podcast channel %s not found", id)
+	}
+	channel := convertToDomainChannel(channels[0])
+	sort.SliceStable(channel.Episodes, func(i, j int) bool {
+		return channel.Episodes[i].PublishDate.After(channel.Episodes[j].PublishDate)
+	})
+	return &channel, nil
+}
+
+func (s *SubsonicLibrary) GetNewestPodcasts(ctx context.Context, limit int) ([]domain.PodcastEpisode, error) {
+	episodes, err := s.client.GetNewestPodcasts(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.PodcastEpisode, len(episodes))
+	for i, e := range episodes {
+		result[i] = convertToDomainEpisode(e)
+	}
+	return result, nil
+}
+
+func (s *SubsonicLibrary) RefreshPodcasts(ctx context.Context) error {
+	return s.client.RefreshPodcasts(ctx)
+}
+
+func (s *SubsonicLibrary) CreatePodcastChannel(ctx context.Context, feedURL string) error {
+	return s.client.CreatePodcastChannel(ctx, feedURL)
+}
+
+func (s *SubsonicLibrary) DeletePodcastChannel(ctx context.Context, id string) error {
+	return s.client.DeletePodcastChannel(ctx, id)
+}
+
+func (s *SubsonicLibrary) DownloadPodcastEpisode(ctx context.Context, id string) error {
+	return s.client.DownloadPodcastEpisode(ctx, id)
+}
+
+func convertToDomainChannel(ch subsonic.PodcastChannel) domain.PodcastChannel {
+	episodes := make([]domain.PodcastEpisode, len(ch.Episodes))
+	for i, e := range ch.Episodes {
+		episodes[i] = convertToDomainEpisode(e)
+	}
+	return domain.PodcastChannel{
+		ID:          ch.ID,
+		URL:         ch.URL,
+		Title:       ch.Title,
+		Description: ch.Description,
+		Status:      ch.Status,
+		Error:       ch.ErrorMessage,
+		Episodes:    episodes,
+	}
+}
+
+func convertToDomainEpisode(e subsonic.PodcastEpisode) domain.PodcastEpisode {
+	song := convertToDomainSong(e.Song)
+	song.ID = e.StreamID
+	song.IsPodcast = true
+	return domain.PodcastEpisode{
+		ID:          e.ID,
+		ChannelID:   e.ChannelID,
+		Title:       e.Title,
+		Description: e.Description,
+		Status:      e.Status,
+		PublishDate: e.PublishDate,
+		Song:        song,
+	}
+}
+
+func convertToDomainArtist(a subsonic.ArtistID3) domain.Artist {
+	albums := make([]domain.Album, len(a.Albums))
+	for i, album := range a.Albums {
+		albums[i] = convertToDomainAlbum(album)
+	}
+	return domain.Artist{
+		ID:         a.ID,
+		Name:       a.Name,
+		AlbumCount: a.AlbumCount,
+		CoverArt:   a.CoverArt,
+		Starred:    timePtr(a.Starred),
+		Albums:     albums,
+	}
+}
+
+func convertToDomainAlbum(a subsonic.AlbumID3) domain.Album {
+	return domain.Album{
+		ID:        a.ID,
+		Name:      a.Name,
+		Artist:    a.Artist,
+		ArtistID:  a.ArtistID,
+		Year:      a.Year,
+		Genre:     a.Genre,
+		SongCount: a.SongCount,
+		Duration:  a.Duration,
+		CoverArt:  a.CoverArt,
+		Starred:   timePtr(a.Starred),
+		Songs:     convertToDomainSongs(a.Songs),
+	}
+}
+
+func convertToDomainPlaylist(p subsonic.Playlist) domain.Playlist {
+	return domain.Playlist{
+		ID:        p.ID,
+		Name:      p.Name,
+		Comment:   p.Comment,
+		Owner:     p.Owner,
+		Public:    p.Public,
+		SongCount: p.SongCount,
+		Duration:  p.Duration,
+		Changed:   p.Changed,
+		Songs:     convertToDomainSongs(p.Entries),
+	}
 }
 
 func convertToDomainSongs(songs []subsonic.Song) []domain.Song {
@@ -86,11 +752,6 @@ func convertToDomainSongs(songs []subsonic.Song) []domain.Song {
 }
 
 func convertToDomainSong(song subsonic.Song) domain.Song {
-	var played *time.Time
-	if !song.Played.IsZero() {
-		played = &song.Played
-	}
-
 	return domain.Song{
 		ID:           song.ID,
 		Title:        song.Title,
@@ -98,6 +759,9 @@ func convertToDomainSong(song subsonic.Song) domain.Song {
 		Artist:       song.Artist,
 		Duration:     song.Duration,
 		Track:        song.Track,
+		DiscNumber:   song.DiscNumber,
+		Year:         song.Year,
+		Genre:        song.Genre,
 		CoverArt:     song.CoverArt,
 		Size:         song.Size,
 		ContentType:  song.ContentType,
@@ -109,8 +773,18 @@ func convertToDomainSong(song subsonic.Song) domain.Song {
 		AlbumID:      song.AlbumID,
 		ArtistID:     song.ArtistID,
 		IsVideo:      song.IsVideo,
-		Played:       played,
+		Played:       timePtr(song.Played),
 		ChannelCount: song.ChannelCount,
 		SampleRate:   song.SampleRate,
+		Starred:      timePtr(song.Starred),
+		UserRating:   song.UserRating,
+	}
+}
+
+// timePtr maps the zero time, which the API uses for "never", to nil
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
 	}
+	return &t
 }
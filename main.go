@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"sync"
@@ -35,9 +37,31 @@ func main() {
 		cfg.Player.GetHTTPTimeout(),
 	)
 
+	subsonicClient.Auth = cfg.Server.Auth
+	subsonicClient.APIKey = cfg.Server.APIKey
+	subsonicClient.Headers = make(http.Header)
+	for name, value := range cfg.Server.Headers {
+		subsonicClient.Headers.Set(name, value)
+	}
+
 	lib := library.NewSubsonicLibrary(subsonicClient)
 
-	if err := lib.Ping(); err != nil {
+	if _, err := lib.Connect(ctx); err != nil {
+		var apiErr *subsonic.Error
+		if errors.As(err, &apiErr) {
+			switch apiErr.Code {
+			case subsonic.ErrWrongCredentials:
+				log.Fatalf("Wrong username or password for %s, check the [server] section of the config", cfg.Server.URL)
+			case subsonic.ErrTokenAuthUnsupported:
+				log.Fatalf("%s does not support token authentication, set auth = \"password\" in the [server] section of the config", cfg.Server.URL)
+			case subsonic.ErrAuthUnsupported:
+				log.Fatalf("%s does not support auth = %q, choose another method in the [server] section of the config", cfg.Server.URL, cfg.Server.Auth)
+			case subsonic.ErrConflictingAuth:
+				log.Fatalf("%s received more than one authentication method, check auth and api_key in the [server] section of the config", cfg.Server.URL)
+			case subsonic.ErrInvalidAPIKey:
+				log.Fatalf("The API key was rejected by %s, check api_key in the [server] section of the config", cfg.Server.URL)
+			}
+		}
 		log.Fatalf("Can not connect to server %s, error: %v", cfg.Server.URL, err)
 	}
 
@@ -45,6 +69,7 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to create player: %v", err)
 	}
+	plr.SetHeaders(cfg.Server.URL, cfg.Server.Headers)
 
 	app := ui.NewApp(ctx, cfg, lib, plr)
 
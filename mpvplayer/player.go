@@ -14,6 +14,10 @@ const (
 	PlayerError
 )
 
+// MetadataObserver is the reply userdata of property-change events for the
+// "metadata" property, which changes when a stream announces a new title
+const MetadataObserver uint64 = 1
+
 type QueueItem struct {
 	Id       string
 	Uri      string
@@ -54,9 +58,40 @@ func (m *Mpvplayer) GetVolume() (float64, error) {
 }
 
 func (m *Mpvplayer) Play(playURL string) {
+	m.SetPropertyString("start", "none")
 	m.Command([]string{"loadfile", playURL})
 }
 
+// PlayAt loads playURL and starts it at start seconds. The start option
+// applies to every file loaded after it is set, so Play resets it.
+func (m *Mpvplayer) PlayAt(playURL string, start float64) error {
+	if err := m.SetPropertyString("start", fmt.Sprintf("%.3f", start)); err != nil {
+		return err
+	}
+	return m.Command([]string{"loadfile", playURL})
+}
+
+// SetHeaderFields replaces the extra HTTP headers sent when loading a URL.
+// Each field is a "Name: value" line; change-list keeps commas in values
+// intact, which setting the whole list at once would split on.
+func (m *Mpvplayer) SetHeaderFields(fields []string) error {
+	if err := m.Command([]string{"change-list", "http-header-fields", "clr", ""}); err != nil {
+		return err
+	}
+	for _, field := range fields {
+		if err := m.Command([]string{"change-list", "http-header-fields", "append", field}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StreamTitle returns the ICY title announced by the playing stream, or "" if
+// it has none
+func (m *Mpvplayer) StreamTitle() string {
+	return m.GetPropertyString("metadata/by-key/icy-title")
+}
+
 func (m *Mpvplayer) Stop() error {
 	return m.Command([]string{"stop"})
 }
@@ -128,6 +163,7 @@ func CreateMPVInstance() (*mpv.Mpv, error) {
 	mpvInstance.SetOptionString("video", "no")
 	mpvInstance.ObserveProperty(0, "cache-buffering-state", mpv.FORMAT_INT64)
 	mpvInstance.ObserveProperty(0, "demuxer-cache-duration", mpv.FORMAT_INT64)
+	mpvInstance.ObserveProperty(MetadataObserver, "metadata", mpv.FORMAT_NONE)
 
 	err := mpvInstance.Initialize()
 	if err != nil {
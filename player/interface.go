@@ -11,12 +11,27 @@ type Player interface {
 	// Play starts playback of the given URL
 	Play(url string) error
 
+	// PlayAt starts playback of the given URL at start seconds
+	PlayAt(url string, start float64) error
+
+	// PlayOffset plays a stream the server already started offset seconds
+	// into the song; progress is reported relative to the whole song
+	PlayOffset(url string, offset float64) error
+
+	// SetHeaders sends extra HTTP headers with every URL on the server at
+	// serverURL: same scheme, host and port, under its path. Call it before
+	// playing anything.
+	SetHeaders(serverURL string, headers map[string]string)
+
 	// Pause toggles the pause state
 	Pause() (int, error)
 
 	// Stop stops playback completely
 	Stop() error
 
+	// StreamTitle returns the live title announced by an internet radio stream
+	StreamTitle() (string, error)
+
 	// GetProgress returns the current playback position and total duration
 	GetProgress() (currentPos, totalDuration float64, err error)
 
@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/wildeyedskies/go-mpv/mpv"
@@ -13,6 +17,13 @@ import (
 
 type MPVPlayer struct {
 	instance *mpvplayer.Mpvplayer
+	offsetMu sync.Mutex
+	offset   float64 // seconds of the song the server skipped, see PlayOffset
+
+	headerServer *url.URL // server the header fields are sent to, see SetHeaders
+	headerFields []string // "Name: value" lines
+	headersMu    sync.Mutex
+	headersOn    bool // whether mpv currently sends headerFields
 }
 
 func NewMPVPlayer(ctx context.Context) (*MPVPlayer, error) {
@@ -36,10 +47,105 @@ func (p *MPVPlayer) Play(url string) error {
 	if p.instance == nil || p.instance.Mpv == nil {
 		return fmt.Errorf("MPV instance not initialized")
 	}
+	p.setOffset(0)
+	p.applyHeaders(url)
+	p.instance.Play(url)
+	return nil
+}
+
+func (p *MPVPlayer) PlayAt(url string, start float64) error {
+	if p.instance == nil || p.instance.Mpv == nil {
+		return fmt.Errorf("MPV instance not initialized")
+	}
+	p.setOffset(0)
+	p.applyHeaders(url)
+	return p.instance.PlayAt(url, start)
+}
+
+func (p *MPVPlayer) PlayOffset(url string, offset float64) error {
+	if p.instance == nil || p.instance.Mpv == nil {
+		return fmt.Errorf("MPV instance not initialized")
+	}
+	p.setOffset(offset)
+	p.applyHeaders(url)
 	p.instance.Play(url)
 	return nil
 }
 
+func (p *MPVPlayer) SetHeaders(serverURL string, headers map[string]string) {
+	server, err := url.Parse(serverURL)
+	if err != nil {
+		log.Printf("Ignoring stream headers, bad server URL: %v", err)
+		server = nil
+	}
+	p.headerServer = server
+	p.headerFields = p.headerFields[:0]
+	for name, value := range headers {
+		p.headerFields = append(p.headerFields, name+": "+value)
+	}
+	sort.Strings(p.headerFields)
+}
+
+// applyHeaders turns the header fields on for URLs on the server and off for
+// anything else, so internet radio hosts never see them
+func (p *MPVPlayer) applyHeaders(streamURL string) {
+	want := len(p.headerFields) > 0 && onServer(p.headerServer, streamURL)
+	p.headersMu.Lock()
+	defer p.headersMu.Unlock()
+	if want == p.headersOn {
+		return
+	}
+	fields := p.headerFields
+	if !want {
+		fields = nil
+	}
+	if err := p.instance.SetHeaderFields(fields); err != nil {
+		log.Printf("Failed to set stream headers: %v", err)
+		return
+	}
+	p.headersOn = want
+}
+
+// onServer reports whether rawURL points at server: the same scheme, host and
+// port, and a path at or below the server's. A plain string prefix would also
+// match http://music.example.com.evil.net for http://music.example.com.
+func onServer(server *url.URL, rawURL string) bool {
+	if server == nil {
+		return false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	if !strings.EqualFold(u.Scheme, server.Scheme) ||
+		!strings.EqualFold(u.Hostname(), server.Hostname()) ||
+		effectivePort(u) != effectivePort(server) {
+		return false
+	}
+	base := strings.TrimSuffix(server.Path, "/")
+	return u.Path == base || strings.HasPrefix(u.Path, base+"/")
+}
+
+// effectivePort returns the port of u, filling in the scheme's default
+func effectivePort(u *url.URL) string {
+	if port := u.Port(); port != "" {
+		return port
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http":
+		return "80"
+	case "https":
+		return "443"
+	}
+	return ""
+}
+
+func (p *MPVPlayer) setOffset(offset float64) {
+	p.offsetMu.Lock()
+	p.offset = offset
+	p.offsetMu.Unlock()
+}
+
 func (p *MPVPlayer) Pause() (int, error) {
 	if p.instance == nil {
 		return PlayerError, fmt.Errorf("MPV instance not initialized")
@@ -54,6 +160,19 @@ func (p *MPVPlayer) Stop() error {
 	return p.instance.Stop()
 }
 
+func (p *MPVPlayer) StreamTitle() (string, error) {
+	if p.instance == nil || p.instance.Mpv == nil {
+		return "", fmt.Errorf("MPV instance not initialized")
+	}
+	return p.instance.StreamTitle(), nil
+}
+
+// IsMetadataChange reports whether event says the playing stream's metadata,
+// such as its ICY title, changed
+func IsMetadataChange(event *mpv.Event) bool {
+	return event.Event_Id == mpv.EVENT_PROPERTY_CHANGE && event.Reply_Userdata == mpvplayer.MetadataObserver
+}
+
 func (p *MPVPlayer) GetProgress() (currentPos, totalDuration float64, err error) {
 	if p.instance == nil || p.instance.Mpv == nil {
 		return 0, 0, fmt.Errorf("MPV instance not initialized")
@@ -76,7 +195,10 @@ func (p *MPVPlayer) GetProgress() (currentPos, totalDuration float64, err error)
 	if !ok {
 		return 0, 0, fmt.Errorf("unexpected type for duration: %T", duration)
 	}
-	return posVal, durVal, nil
+	p.offsetMu.Lock()
+	offset := p.offset
+	p.offsetMu.Unlock()
+	return posVal + offset, durVal + offset, nil
 }
 
 func (p *MPVPlayer) GetVolume() (float64, error) {
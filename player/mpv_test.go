@@ -0,0 +1,32 @@
+package player
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestOnServer(t *testing.T) {
+	server, _ := url.Parse("https://music.example.com/navidrome/")
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://music.example.com/navidrome/rest/stream.view?id=1", true},
+		{"https://MUSIC.example.com:443/navidrome/rest/stream.view", true},
+		{"https://music.example.com/navidrome", true},
+		{"https://music.example.com.evil.net/navidrome/rest/stream.view", false},
+		{"https://music.example.com:8443/navidrome/rest/stream.view", false},
+		{"http://music.example.com/navidrome/rest/stream.view", false},
+		{"https://music.example.com/navidrome-other/stream", false},
+		{"https://music.example.com/rest/stream.view", false},
+		{"http://radio.example.net/live", false},
+	}
+	for _, tt := range tests {
+		if got := onServer(server, tt.url); got != tt.want {
+			t.Errorf("onServer(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+	if onServer(nil, "https://music.example.com/navidrome/rest/stream.view") {
+		t.Error("onServer without a server matched")
+	}
+}
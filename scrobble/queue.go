@@ -0,0 +1,150 @@
+package scrobble
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Submission is a finished play that has not reached the server yet.
+type Submission struct {
+	SongID   string    `json:"song_id"`
+	PlayedAt time.Time `json:"played_at"`
+}
+
+// Queue keeps failed submissions on disk so they survive restarts and can be
+// retried once the server is reachable again.
+type Queue struct {
+	path    string
+	mu      sync.Mutex
+	items   []Submission
+	flushMu sync.Mutex // one Flush at a time
+}
+
+// DefaultPath returns the location of the pending scrobble file, next to the
+// config file in ~/.config/navicli.
+func DefaultPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "navicli", "scrobbles.json")
+}
+
+// NewQueue loads pending submissions from path. An empty path keeps the queue
+// in memory only.
+//
+// A file that cannot be parsed is moved aside to path + ".bad", so the
+// submissions in it are not overwritten by the next save; the returned queue
+// starts empty. If the file can neither be read nor moved aside, the queue is
+// kept in memory only.
+func NewQueue(path string) (*Queue, error) {
+	q := &Queue{path: path}
+	if path == "" {
+		return q, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return q, nil
+	}
+	if err != nil {
+		q.path = ""
+		return q, fmt.Errorf("read scrobble queue: %w", err)
+	}
+	if err := json.Unmarshal(data, &q.items); err != nil {
+		q.items = nil
+		bad := path + ".bad"
+		if renameErr := os.Rename(path, bad); renameErr != nil {
+			q.path = ""
+			return q, fmt.Errorf("parse scrobble queue: %w (keeping it in memory only, moving the file failed: %v)", err, renameErr)
+		}
+		return q, fmt.Errorf("parse scrobble queue: %w (moved to %s)", err, bad)
+	}
+	return q, nil
+}
+
+// Add appends a submission and persists the queue.
+func (q *Queue) Add(s Submission) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.items = append(q.items, s)
+	return q.save()
+}
+
+// Len returns the number of pending submissions.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// Flush sends every pending submission in order. Submissions that fail with
+// an error keep accepts stay queued, for example because the server was
+// unreachable; any other failure is permanent and drops the submission. It
+// returns how many were delivered. The queue is not locked while sending, so
+// Add does not wait on the network.
+func (q *Queue) Flush(send func(Submission) error, keep func(error) bool) (int, error) {
+	q.flushMu.Lock()
+	defer q.flushMu.Unlock()
+
+	q.mu.Lock()
+	pending := append([]Submission(nil), q.items...)
+	q.mu.Unlock()
+
+	sent := 0
+	var remaining []Submission
+	for _, s := range pending {
+		if err := send(s); err != nil {
+			if keep(err) {
+				remaining = append(remaining, s)
+			}
+			continue
+		}
+		sent++
+	}
+	if len(remaining) == len(pending) {
+		return 0, nil
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	// only Flush removes items, so whatever was added meanwhile follows the
+	// snapshot
+	q.items = append(remaining, q.items[len(pending):]...)
+	return sent, q.save()
+}
+
+func (q *Queue) save() error {
+	if q.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(q.path), 0o755); err != nil {
+		return fmt.Errorf("create scrobble dir: %w", err)
+	}
+	data, err := json.Marshal(q.items)
+	if err != nil {
+		return fmt.Errorf("encode scrobble queue: %w", err)
+	}
+	tmp := q.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write scrobble queue: %w", err)
+	}
+	return os.Rename(tmp, q.path)
+}
+
+// Threshold returns the playback position, in seconds, at which a track of the
+// given length counts as played: half its duration or four minutes, whichever
+// comes first.
+func Threshold(duration int) float64 {
+	const maxWait = 4 * 60
+	half := float64(duration) / 2
+	if half > maxWait {
+		return maxWait
+	}
+	return half
+}
@@ -0,0 +1,110 @@
+package scrobble
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var errOffline = errors.New("offline")
+
+func isOffline(err error) bool { return errors.Is(err, errOffline) }
+
+func TestQueuePersistsFailedSubmissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scrobbles.json")
+
+	q, err := NewQueue(path)
+	if err != nil {
+		t.Fatalf("NewQueue: %v", err)
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, id := range []string{"a", "b", "c"} {
+		if err := q.Add(Submission{SongID: id, PlayedAt: now}); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	sent, err := q.Flush(func(s Submission) error {
+		switch s.SongID {
+		case "b":
+			return errOffline
+		case "c":
+			return errors.New("song not found")
+		}
+		return nil
+	}, isOffline)
+	if err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if sent != 1 {
+		t.Errorf("expected 1 submission sent, got %d", sent)
+	}
+
+	reloaded, err := NewQueue(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if reloaded.Len() != 1 {
+		t.Fatalf("expected 1 pending submission after reload, got %d", reloaded.Len())
+	}
+	if reloaded.items[0].SongID != "b" || !reloaded.items[0].PlayedAt.Equal(now) {
+		t.Errorf("unexpected pending submission: %+v", reloaded.items[0])
+	}
+}
+
+func TestQueueAddDuringFlush(t *testing.T) {
+	q, _ := NewQueue("")
+	q.Add(Submission{SongID: "a"})
+
+	sent, err := q.Flush(func(s Submission) error {
+		// Add must not block on a flush in progress, and its item must survive
+		if err := q.Add(Submission{SongID: "b"}); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+		return nil
+	}, isOffline)
+	if err != nil || sent != 1 {
+		t.Fatalf("Flush = %d, %v, want 1, nil", sent, err)
+	}
+	if q.Len() != 1 || q.items[0].SongID != "b" {
+		t.Errorf("pending = %+v, want only b", q.items)
+	}
+}
+
+func TestQueueMovesUnreadableFileAside(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scrobbles.json")
+	if err := os.WriteFile(path, []byte(`[{"song_id":"a"`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	q, err := NewQueue(path)
+	if err == nil {
+		t.Fatal("NewQueue succeeded on a truncated file")
+	}
+	if err := q.Add(Submission{SongID: "b"}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	bad, err := os.ReadFile(path + ".bad")
+	if err != nil {
+		t.Fatalf("bad file: %v", err)
+	}
+	if string(bad) != `[{"song_id":"a"` {
+		t.Errorf("bad file = %q, want the original contents", bad)
+	}
+	reloaded, err := NewQueue(path)
+	if err != nil || reloaded.Len() != 1 {
+		t.Errorf("reload = %d items, %v; want only the new submission", reloaded.Len(), err)
+	}
+}
+
+func TestThreshold(t *testing.T) {
+	if got := Threshold(180); got != 90 {
+		t.Errorf("Threshold(180) = %v, want 90", got)
+	}
+	if got := Threshold(1200); got != 240 {
+		t.Errorf("Threshold(1200) = %v, want 240", got)
+	}
+}
@@ -0,0 +1,30 @@
+package subsonic
+
+import (
+	"context"
+	"net/url"
+)
+
+// GetArtists returns all artists grouped by index letter.
+func (c *Client) GetArtists(ctx context.Context) ([]ArtistIndex, error) {
+	var result struct {
+		Artists struct {
+			Index []ArtistIndex `json:"index"`
+		} `json:"artists"`
+	}
+	if err := c.request(ctx, "getArtists", nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Artists.Index, nil
+}
+
+// GetArtist returns an artist with its albums.
+func (c *Client) GetArtist(ctx context.Context, artistID string) (*ArtistID3, error) {
+	var result struct {
+		Artist ArtistID3 `json:"artist"`
+	}
+	if err := c.request(ctx, "getArtist", url.Values{"id": {artistID}}, &result); err != nil {
+		return nil, err
+	}
+	return &result.Artist, nil
+}
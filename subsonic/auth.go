@@ -3,12 +3,20 @@ package subsonic
 import (
 	"crypto/md5"
 	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"net/url"
 	"time"
 )
 
+// Authentication methods of the Client's Auth field
+const (
+	AuthToken    = "token"    // salted md5 token, the Subsonic default
+	AuthPassword = "password" // hex-encoded password, for servers that store hashed passwords
+	AuthAPIKey   = "apikey"   // OpenSubsonic apiKey parameter, no username
+)
+
 const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
 
 func randSeq(n int) (string, error) {
@@ -32,6 +40,7 @@ func Init(baseUrl, username, password, clientId, apiVersion string, pageSize int
 		APIVersion: apiVersion,
 		PageSize:   pageSize,
 		HttpClient: &http.Client{Timeout: httpTimeout},
+		breaker:    newBreaker(breakerThreshold, breakerCooldown),
 	}
 	return client
 }
@@ -47,14 +56,22 @@ func (c *Client) authToken(password string) (string, string, error) {
 }
 
 func (c *Client) buildParams(extraParams map[string]string) (url.Values, error) {
-	token, salt, err := c.authToken(c.Password)
-	if err != nil {
-		return nil, fmt.Errorf("auth token: %w", err)
-	}
 	params := url.Values{}
-	params.Add("u", c.Username)
-	params.Add("t", token)
-	params.Add("s", salt)
+	switch c.Auth {
+	case AuthAPIKey:
+		params.Add("apiKey", c.APIKey)
+	case AuthPassword:
+		params.Add("u", c.Username)
+		params.Add("p", "enc:"+hex.EncodeToString([]byte(c.Password)))
+	default:
+		token, salt, err := c.authToken(c.Password)
+		if err != nil {
+			return nil, fmt.Errorf("auth token: %w", err)
+		}
+		params.Add("u", c.Username)
+		params.Add("t", token)
+		params.Add("s", salt)
+	}
 	params.Add("v", c.APIVersion)
 	params.Add("c", c.ClientID)
 	params.Add("f", "json")
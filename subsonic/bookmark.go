@@ -0,0 +1,47 @@
+package subsonic
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Bookmark is a saved position within a song
+type Bookmark struct {
+	Position int64     `json:"position"` // in milliseconds
+	Username string    `json:"username"`
+	Comment  string    `json:"comment"`
+	Created  time.Time `json:"created"`
+	Changed  time.Time `json:"changed"`
+	Entry    Song      `json:"entry"`
+}
+
+// CreateBookmark saves position (in milliseconds) for a song, replacing any
+// earlier bookmark the user had on it
+func (c *Client) CreateBookmark(ctx context.Context, songID string, position int64, comment string) error {
+	params := url.Values{
+		"id":       {songID},
+		"position": {strconv.FormatInt(position, 10)},
+	}
+	if comment != "" {
+		params.Set("comment", comment)
+	}
+	return c.request(ctx, "createBookmark", params, nil)
+}
+
+func (c *Client) DeleteBookmark(ctx context.Context, songID string) error {
+	return c.request(ctx, "deleteBookmark", url.Values{"id": {songID}}, nil)
+}
+
+func (c *Client) GetBookmarks(ctx context.Context) ([]Bookmark, error) {
+	var result struct {
+		Bookmarks struct {
+			Bookmarks []Bookmark `json:"bookmark"`
+		} `json:"bookmarks"`
+	}
+	if err := c.request(ctx, "getBookmarks", nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Bookmarks.Bookmarks, nil
+}
@@ -0,0 +1,307 @@
+package subsonic
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// decodeResponse checks the status of a subsonic-response envelope and
+// decodes its contents into out.
+//
+// Servers other than Navidrome do not always answer the way the JSON API
+// documents: some send XML despite f=json, older ones send numeric IDs, a
+// lone list entry as an object instead of an array, and dates in other
+// formats. The body is therefore parsed into a generic tree first, which
+// normalize reshapes to the types of out before the final JSON decode.
+func decodeResponse(body []byte, out interface{}) error {
+	var contents map[string]interface{}
+	var err error
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '<' {
+		contents, err = parseXMLResponse(trimmed)
+	} else {
+		contents, err = parseJSONResponse(body)
+	}
+	if err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	var status struct {
+		Status string `json:"status"`
+		Error  *Error `json:"error"`
+	}
+	if err := decodeTree(contents, &status); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	if status.Status != "ok" {
+		if status.Error == nil {
+			return &Error{Code: ErrGeneric, Message: "request failed"}
+		}
+		return status.Error
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := decodeTree(contents, out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+func parseJSONResponse(body []byte) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var envelope struct {
+		SubsonicResponse map[string]interface{} `json:"subsonic-response"`
+	}
+	if err := dec.Decode(&envelope); err != nil {
+		return nil, err
+	}
+	if envelope.SubsonicResponse == nil {
+		return nil, errors.New("no subsonic-response")
+	}
+	return envelope.SubsonicResponse, nil
+}
+
+// parseXMLResponse turns an XML subsonic-response into the tree its JSON
+// form would decode to. Attributes become fields, child elements become
+// lists under their name and text content becomes "value", as in the JSON API.
+func parseXMLResponse(body []byte) (map[string]interface{}, error) {
+	dec := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil, errors.New("no subsonic-response")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			if start.Name.Local != "subsonic-response" {
+				return nil, fmt.Errorf("unexpected root element %q", start.Name.Local)
+			}
+			return parseXMLElement(dec, start)
+		}
+	}
+}
+
+func parseXMLElement(dec *xml.Decoder, start xml.StartElement) (map[string]interface{}, error) {
+	node := make(map[string]interface{}, len(start.Attr))
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		node[attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			child, err := parseXMLElement(dec, t)
+			if err != nil {
+				return nil, err
+			}
+			list, _ := node[t.Name.Local].([]interface{})
+			node[t.Name.Local] = append(list, child)
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			if value := strings.TrimSpace(text.String()); value != "" {
+				node["value"] = value
+			}
+			return node, nil
+		}
+	}
+}
+
+// decodeTree normalizes tree to the type of out and decodes it into out
+func decodeTree(tree map[string]interface{}, out interface{}) error {
+	data, err := json.Marshal(normalize(tree, reflect.TypeOf(out)))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// normalize reshapes v to what encoding/json expects for type t. Lists are
+// unwrapped or wrapped to match the field, numbers and strings are converted
+// either way, dates are rewritten as RFC 3339 and anything that cannot be
+// converted is dropped, leaving the field at its zero value.
+func normalize(v interface{}, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if list, ok := v.([]interface{}); ok && t.Kind() != reflect.Slice && t.Kind() != reflect.Interface {
+		if len(list) == 0 {
+			return nil
+		}
+		v = list[0]
+	}
+
+	if t == timeType {
+		s, ok := v.(string)
+		if !ok {
+			return nil
+		}
+		parsed, ok := parseTime(s)
+		if !ok {
+			return nil
+		}
+		return parsed.Format(time.RFC3339Nano)
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		result := make(map[string]interface{}, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+				// encoding/json promotes the fields of embedded structs
+				if embedded, ok := normalize(m, field.Type).(map[string]interface{}); ok {
+					for k, value := range embedded {
+						result[k] = value
+					}
+				}
+				continue
+			}
+			name := jsonName(field)
+			if name == "" {
+				continue
+			}
+			if value, ok := m[name]; ok {
+				if n := normalize(value, field.Type); n != nil {
+					result[name] = n
+				}
+			}
+		}
+		return result
+	case reflect.Slice:
+		list, ok := v.([]interface{})
+		if !ok {
+			list = []interface{}{v}
+		}
+		result := make([]interface{}, 0, len(list))
+		for _, item := range list {
+			if n := normalize(item, t.Elem()); n != nil {
+				result = append(result, n)
+			}
+		}
+		return result
+	case reflect.Map:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		result := make(map[string]interface{}, len(m))
+		for k, item := range m {
+			if n := normalize(item, t.Elem()); n != nil {
+				result[k] = n
+			}
+		}
+		return result
+	case reflect.String:
+		switch s := v.(type) {
+		case string:
+			return s
+		case json.Number:
+			return s.String()
+		case bool:
+			return strconv.FormatBool(s)
+		case map[string]interface{}:
+			if value, ok := s["value"].(string); ok {
+				return value
+			}
+		}
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s := numberText(v)
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return int64(f)
+		}
+		return nil
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(numberText(v), 64); err == nil {
+			return f
+		}
+		return nil
+	case reflect.Bool:
+		switch b := v.(type) {
+		case bool:
+			return b
+		case string:
+			if parsed, err := strconv.ParseBool(b); err == nil {
+				return parsed
+			}
+		}
+		return nil
+	}
+	return v
+}
+
+func jsonName(field reflect.StructField) string {
+	if field.PkgPath != "" {
+		return ""
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name
+	}
+	return field.Name
+}
+
+func numberText(v interface{}) string {
+	switch n := v.(type) {
+	case json.Number:
+		return n.String()
+	case string:
+		return strings.TrimSpace(n)
+	}
+	return ""
+}
+
+// timeLayouts are the date formats seen from Subsonic servers. Dates without
+// a zone are taken as UTC.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+func parseTime(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,160 @@
+package subsonic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fixtureClient returns a client whose server answers each endpoint with
+// testdata/<family>_<endpoint>.json or .xml, as saved from that server family
+func fixtureClient(t *testing.T, family string) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		endpoint := strings.TrimSuffix(path.Base(r.URL.Path), ".view")
+		for _, ext := range []string{".json", ".xml"} {
+			body, err := os.ReadFile(filepath.Join("testdata", family+"_"+endpoint+ext))
+			if err == nil {
+				w.Write(body)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return Init(srv.URL, "user", "pass", "test", "1.16.1", 20, 5*time.Second)
+}
+
+func TestDecodeGonicAlbum(t *testing.T) {
+	album, err := fixtureClient(t, "gonic").GetAlbum(context.Background(), "al-412")
+	if err != nil {
+		t.Fatalf("GetAlbum: %v", err)
+	}
+	if album.ID != "al-412" || album.ArtistID != "ar-37" || len(album.Songs) != 2 {
+		t.Fatalf("unexpected album %+v", album)
+	}
+	song := album.Songs[1]
+	if song.ID != "tr-5522" || song.Duration != 313 || song.SampleRate != 0 {
+		t.Errorf("unexpected song %+v", song)
+	}
+	if want := time.Date(2023, 11, 2, 18, 41, 7, 613421337, time.UTC); !song.Created.Equal(want) {
+		t.Errorf("created = %v, want %v", song.Created, want)
+	}
+	if song.Starred.IsZero() || !album.Songs[0].Starred.IsZero() {
+		t.Errorf("starred = %v / %v, want only the second song starred", album.Songs[0].Starred, song.Starred)
+	}
+}
+
+func TestDecodeGonicGenres(t *testing.T) {
+	genres, err := fixtureClient(t, "gonic").GetGenres(context.Background())
+	if err != nil {
+		t.Fatalf("GetGenres: %v", err)
+	}
+	if len(genres) != 2 || genres[0].Name != "Electronic" || genres[0].SongCount != 212 {
+		t.Errorf("unexpected genres %+v", genres)
+	}
+}
+
+func TestDecodeAirsonicXML(t *testing.T) {
+	songs, err := fixtureClient(t, "airsonic").GetRandomSongs(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("GetRandomSongs: %v", err)
+	}
+	if len(songs) != 2 {
+		t.Fatalf("expected 2 songs, got %d", len(songs))
+	}
+	got := songs[0]
+	if got.ID != "1843" || got.Title != "So What" || got.Duration != 562 || got.Size != 14873312 ||
+		got.PlayCount != 12 || got.UserRating != 4 || got.IsVideo {
+		t.Errorf("unexpected song %+v", got)
+	}
+	if want := time.Date(2021, 3, 4, 10, 11, 12, 0, time.UTC); !got.Created.Equal(want) {
+		t.Errorf("created = %v, want %v", got.Created, want)
+	}
+	if songs[1].Starred.IsZero() {
+		t.Errorf("second song should be starred")
+	}
+}
+
+func TestDecodeAirsonicXMLText(t *testing.T) {
+	lyrics, err := fixtureClient(t, "airsonic").GetLyrics(context.Background(), "Miles Davis", "So What")
+	if err != nil {
+		t.Fatalf("GetLyrics: %v", err)
+	}
+	if lyrics.Artist != "Miles Davis" || lyrics.Value != "Instrumental" {
+		t.Errorf("unexpected lyrics %+v", lyrics)
+	}
+}
+
+func TestDecodeXMLError(t *testing.T) {
+	err := decodeResponse(mustRead(t, "airsonic_error.xml"), nil)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Code != ErrWrongCredentials || apiErr.Message != "Wrong username or password" {
+		t.Errorf("expected wrong credentials error, got %v", err)
+	}
+}
+
+func TestDecodeLegacySubsonicAlbum(t *testing.T) {
+	album, err := fixtureClient(t, "subsonic").GetAlbum(context.Background(), "118")
+	if err != nil {
+		t.Fatalf("GetAlbum: %v", err)
+	}
+	if album.ID != "118" || album.ArtistID != "41" || album.CoverArt != "117" {
+		t.Errorf("numeric IDs not decoded as strings: %+v", album)
+	}
+	if len(album.Songs) != 1 || album.Songs[0].ID != "120" {
+		t.Fatalf("single song object not decoded as a list: %+v", album.Songs)
+	}
+	if want := time.Date(2015, 6, 12, 21, 3, 49, 0, time.UTC); !album.Created.Equal(want) {
+		t.Errorf("created = %v, want %v", album.Created, want)
+	}
+}
+
+func TestDecodeLegacySubsonicPlayQueue(t *testing.T) {
+	queue, err := fixtureClient(t, "subsonic").GetPlayQueue(context.Background())
+	if err != nil {
+		t.Fatalf("GetPlayQueue: %v", err)
+	}
+	if queue.Current != "120" || queue.Position != 95000 || len(queue.Entries) != 1 {
+		t.Errorf("unexpected queue %+v", queue)
+	}
+	if want := time.Date(2016, 1, 3, 18, 22, 10, 0, time.UTC); !queue.Changed.Equal(want) {
+		t.Errorf("changed = %v, want %v", queue.Changed, want)
+	}
+	if !queue.Entries[0].Created.IsZero() {
+		t.Errorf("empty created should decode as the zero time, got %v", queue.Entries[0].Created)
+	}
+}
+
+func TestDecodeLegacySubsonicNowPlaying(t *testing.T) {
+	entries, err := fixtureClient(t, "subsonic").GetNowPlaying(context.Background())
+	if err != nil {
+		t.Fatalf("GetNowPlaying: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	got := entries[0]
+	if got.ID != "120" || got.Title != "Blue Train" || got.Duration != 643 {
+		t.Errorf("embedded song fields not decoded: %+v", got.Song)
+	}
+	if got.Username != "dad" || got.MinutesAgo != 3 || got.PlayerID != "7" || got.PlayerName != "Kitchen" {
+		t.Errorf("unexpected entry %+v", got)
+	}
+}
+
+func mustRead(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
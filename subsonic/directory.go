@@ -0,0 +1,54 @@
+package subsonic
+
+import (
+	"context"
+	"net/url"
+)
+
+// IndexEntry is a top-level directory listed by getIndexes
+type IndexEntry struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// DirectoryIndex groups top-level directories under an index letter
+type DirectoryIndex struct {
+	Name    string       `json:"name"`
+	Entries []IndexEntry `json:"artist"`
+}
+
+// Indexes is the root of the file tree: the top-level directories of the
+// music folders, and any files placed directly in them
+type Indexes struct {
+	Index    []DirectoryIndex `json:"index"`
+	Children []Song           `json:"child"`
+}
+
+// Directory is one directory of the file tree. Children are subdirectories
+// (IsDir set, named by Title) and files.
+type Directory struct {
+	ID       string `json:"id"`
+	Parent   string `json:"parent"`
+	Name     string `json:"name"`
+	Children []Song `json:"child"`
+}
+
+func (c *Client) GetIndexes(ctx context.Context) (*Indexes, error) {
+	var result struct {
+		Indexes Indexes `json:"indexes"`
+	}
+	if err := c.request(ctx, "getIndexes", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result.Indexes, nil
+}
+
+func (c *Client) GetMusicDirectory(ctx context.Context, id string) (*Directory, error) {
+	var result struct {
+		Directory Directory `json:"directory"`
+	}
+	if err := c.request(ctx, "getMusicDirectory", url.Values{"id": {id}}, &result); err != nil {
+		return nil, err
+	}
+	return &result.Directory, nil
+}
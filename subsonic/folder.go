@@ -0,0 +1,50 @@
+package subsonic
+
+import (
+	"context"
+)
+
+// MusicFolder is a top-level media folder. Navidrome calls these libraries.
+type MusicFolder struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// folderScoped lists the endpoints that take musicFolderId. fetch adds the
+// folder set with SetMusicFolder to each of them.
+var folderScoped = map[string]bool{
+	"getRandomSongs":  true,
+	"getAlbumList2":   true,
+	"search3":         true,
+	"getSongsByGenre": true,
+	"getArtists":      true,
+	"getStarred2":     true,
+	"getIndexes":      true,
+}
+
+func (c *Client) GetMusicFolders(ctx context.Context) ([]MusicFolder, error) {
+	var result struct {
+		MusicFolders struct {
+			Folders []MusicFolder `json:"musicFolder"`
+		} `json:"musicFolders"`
+	}
+	if err := c.request(ctx, "getMusicFolders", nil, &result); err != nil {
+		return nil, err
+	}
+	return result.MusicFolders.Folders, nil
+}
+
+// SetMusicFolder limits random songs, album lists, searches, genres, artists
+// and starred items to one music folder. An empty id lifts the limit.
+func (c *Client) SetMusicFolder(id string) {
+	c.folderMu.Lock()
+	c.musicFolder = id
+	c.folderMu.Unlock()
+}
+
+// MusicFolder returns the ID of the folder set with SetMusicFolder
+func (c *Client) MusicFolder() string {
+	c.folderMu.RLock()
+	defer c.folderMu.RUnlock()
+	return c.musicFolder
+}
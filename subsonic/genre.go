@@ -0,0 +1,48 @@
+package subsonic
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+type Genre struct {
+	Name       string `json:"value"`
+	SongCount  int    `json:"songCount"`
+	AlbumCount int    `json:"albumCount"`
+}
+
+func (c *Client) GetGenres(ctx context.Context) ([]Genre, error) {
+	var result struct {
+		Genres struct {
+			Genres []Genre `json:"genre"`
+		} `json:"genres"`
+	}
+	if err := c.request(ctx, "getGenres", nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Genres.Genres, nil
+}
+
+// GetSongsByGenre returns one page of songs in a genre. The server caps count
+// at 500.
+func (c *Client) GetSongsByGenre(ctx context.Context, genre string, count, offset int) ([]Song, error) {
+	if count <= 0 {
+		count = c.PageSize
+	}
+	params := url.Values{
+		"genre":  {genre},
+		"count":  {fmt.Sprintf("%d", count)},
+		"offset": {fmt.Sprintf("%d", offset)},
+	}
+
+	var result struct {
+		SongsByGenre struct {
+			Songs []Song `json:"song"`
+		} `json:"songsByGenre"`
+	}
+	if err := c.request(ctx, "getSongsByGenre", params, &result); err != nil {
+		return nil, err
+	}
+	return result.SongsByGenre.Songs, nil
+}
@@ -0,0 +1,61 @@
+package subsonic
+
+import (
+	"context"
+	"net/url"
+)
+
+// LyricLine is one line of structured lyrics. Start is in milliseconds and
+// only set for synced lyrics.
+type LyricLine struct {
+	Start int64  `json:"start"`
+	Value string `json:"value"`
+}
+
+// StructuredLyrics is one entry of the OpenSubsonic getLyricsBySongId response
+type StructuredLyrics struct {
+	DisplayArtist string      `json:"displayArtist"`
+	DisplayTitle  string      `json:"displayTitle"`
+	Lang          string      `json:"lang"`
+	Offset        int64       `json:"offset"`
+	Synced        bool        `json:"synced"`
+	Lines         []LyricLine `json:"line"`
+}
+
+// Lyrics is the legacy getLyrics response: plain text, matched by artist and
+// title
+type Lyrics struct {
+	Artist string `json:"artist"`
+	Title  string `json:"title"`
+	Value  string `json:"value"`
+}
+
+// GetLyricsBySongID returns the structured lyrics stored for a song. It is an
+// OpenSubsonic extension, so older servers answer with an error.
+func (c *Client) GetLyricsBySongID(ctx context.Context, songID string) ([]StructuredLyrics, error) {
+	var result struct {
+		LyricsList struct {
+			StructuredLyrics []StructuredLyrics `json:"structuredLyrics"`
+		} `json:"lyricsList"`
+	}
+	if err := c.request(ctx, "getLyricsBySongId", url.Values{"id": {songID}}, &result); err != nil {
+		return nil, err
+	}
+	return result.LyricsList.StructuredLyrics, nil
+}
+
+// GetLyrics returns plain lyrics matched by artist and title
+func (c *Client) GetLyrics(ctx context.Context, artist, title string) (*Lyrics, error) {
+	params := url.Values{
+		"artist": {artist},
+		"title":  {title},
+	}
+
+	var result struct {
+		Lyrics Lyrics `json:"lyrics"`
+	}
+	if err := c.request(ctx, "getLyrics", params, &result); err != nil {
+		return nil, err
+	}
+	return &result.Lyrics, nil
+}
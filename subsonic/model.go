@@ -2,6 +2,7 @@ package subsonic
 
 import (
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -9,17 +10,48 @@ type Client struct {
 	BaseURL    string
 	Username   string
 	Password   string
+	Auth       string // AuthToken, AuthPassword or AuthAPIKey; "" = AuthToken
+	APIKey     string
+	Headers    http.Header // added to every API call, e.g. for an authenticating proxy
 	ClientID   string
 	APIVersion string
 	PageSize   int
 	HttpClient *http.Client
+	breaker    *breaker
+
+	folderMu    sync.RWMutex
+	musicFolder string // see SetMusicFolder
 }
 
 type AlbumID3 struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Artist   string `json:"artist"`
-	SongCount int   `json:"songCount"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Artist    string    `json:"artist"`
+	ArtistID  string    `json:"artistId"`
+	SongCount int       `json:"songCount"`
+	Duration  int       `json:"duration"` // in seconds
+	Year      int       `json:"year"`
+	Genre     string    `json:"genre"`
+	CoverArt  string    `json:"coverArt"`
+	PlayCount int       `json:"playCount"`
+	Created   time.Time `json:"created"`
+	Starred   time.Time `json:"starred,omitempty"`
+	Songs     []Song    `json:"song"` // only filled by getAlbum
+}
+
+type ArtistID3 struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	AlbumCount int        `json:"albumCount"`
+	CoverArt   string     `json:"coverArt"`
+	Starred    time.Time  `json:"starred,omitempty"`
+	Albums     []AlbumID3 `json:"album"` // only filled by getArtist
+}
+
+// ArtistIndex groups artists under an index letter as returned by getArtists.
+type ArtistIndex struct {
+	Name    string      `json:"name"`
+	Artists []ArtistID3 `json:"artist"`
 }
 
 type Song struct {
@@ -29,6 +61,9 @@ type Song struct {
 	Artist       string    `json:"artist"`
 	Duration     int       `json:"duration"` // in seconds
 	Track        int       `json:"track"`
+	DiscNumber   int       `json:"discNumber"`
+	Year         int       `json:"year"`
+	Genre        string    `json:"genre"`
 	CoverArt     string    `json:"coverArt"`
 	Size         int64     `json:"size"`
 	ContentType  string    `json:"contentType"`
@@ -43,4 +78,8 @@ type Song struct {
 	Played       time.Time `json:"played,omitempty"`
 	ChannelCount int       `json:"channelCount"`
 	SampleRate   int       `json:"samplingRate"`
+	Starred      time.Time `json:"starred,omitempty"`
+	UserRating   int       `json:"userRating"`
+	Parent       string    `json:"parent"` // directory in the file tree
+	IsDir        bool      `json:"isDir"`  // set for subdirectories listed by getMusicDirectory
 }
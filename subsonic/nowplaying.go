@@ -0,0 +1,27 @@
+package subsonic
+
+import (
+	"context"
+)
+
+// NowPlayingEntry is a song someone is playing on the server
+type NowPlayingEntry struct {
+	Song
+	Username   string `json:"username"`
+	MinutesAgo int    `json:"minutesAgo"` // since the song started
+	PlayerID   string `json:"playerId"`
+	PlayerName string `json:"playerName"`
+}
+
+// GetNowPlaying lists what every user of the server is playing
+func (c *Client) GetNowPlaying(ctx context.Context) ([]NowPlayingEntry, error) {
+	var result struct {
+		NowPlaying struct {
+			Entries []NowPlayingEntry `json:"entry"`
+		} `json:"nowPlaying"`
+	}
+	if err := c.request(ctx, "getNowPlaying", nil, &result); err != nil {
+		return nil, err
+	}
+	return result.NowPlaying.Entries, nil
+}
@@ -1,145 +1,87 @@
 package subsonic
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
+	"net/url"
 )
 
-func (c *Client) GetRandomSongs(size int) ([]Song, error) {
+func (c *Client) GetRandomSongs(ctx context.Context, size int) ([]Song, error) {
 	if size <= 0 {
 		size = c.PageSize
 	}
-	params, err := c.buildParams(map[string]string{
-		"size":   fmt.Sprintf("%d", size),
-		"format": "json",
-	})
-	if err != nil {
-		return nil, fmt.Errorf("build params: %w", err)
-	}
-	requestUrl := fmt.Sprintf("%s/rest/getRandomSongs?%s", c.BaseURL, params.Encode())
-	req, err := http.NewRequest("GET", requestUrl, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Accept", "application/json")
-	resp, err := c.HttpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status: %d, response: %s", resp.StatusCode, string(body))
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("read response failed: %w", err)
-	}
-
-	var subsonicResp struct {
-		SubsonicResponse struct {
-			Status string `json:"status"`
-			Error  struct {
-				Code    int    `json:"code"`
-				Message string `json:"message"`
-			} `json:"error"`
-			RandomSongs struct {
-				Songs []Song `json:"song"`
-			} `json:"randomSongs"`
-		} `json:"subsonic-response"`
-	}
 
-	if err := json.Unmarshal(body, &subsonicResp); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON response: %w", err)
+	var result struct {
+		RandomSongs struct {
+			Songs []Song `json:"song"`
+		} `json:"randomSongs"`
 	}
-
-	if subsonicResp.SubsonicResponse.Status != "ok" {
-		return nil, fmt.Errorf("subsonic error %d: %s",
-			subsonicResp.SubsonicResponse.Error.Code,
-			subsonicResp.SubsonicResponse.Error.Message)
+	if err := c.request(ctx, "getRandomSongs", url.Values{"size": {fmt.Sprintf("%d", size)}}, &result); err != nil {
+		return nil, err
 	}
+	return result.RandomSongs.Songs, nil
+}
 
-	return subsonicResp.SubsonicResponse.RandomSongs.Songs, nil
+// SearchOptions sets how many artists, albums and songs search3 returns and
+// where each list starts. A count of 0 leaves that kind out.
+type SearchOptions struct {
+	ArtistCount  int
+	ArtistOffset int
+	AlbumCount   int
+	AlbumOffset  int
+	SongCount    int
+	SongOffset   int
 }
 
-func (c *Client) GetServerInfo() error {
-	params, err := c.buildParams(map[string]string{})
-	if err != nil {
-		return fmt.Errorf("build params: %w", err)
-	}
-	requestUrl := fmt.Sprintf("%s/rest/ping.view?%s", c.BaseURL, params.Encode())
+type SearchResult3 struct {
+	Artists []ArtistID3 `json:"artist"`
+	Albums  []AlbumID3  `json:"album"`
+	Songs   []Song      `json:"song"`
+}
 
-	req, err := http.NewRequest("GET", requestUrl, nil)
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+func (c *Client) Search3(ctx context.Context, query string, opts SearchOptions) (*SearchResult3, error) {
+	params := url.Values{
+		"query":        {query},
+		"artistCount":  {fmt.Sprintf("%d", opts.ArtistCount)},
+		"artistOffset": {fmt.Sprintf("%d", opts.ArtistOffset)},
+		"albumCount":   {fmt.Sprintf("%d", opts.AlbumCount)},
+		"albumOffset":  {fmt.Sprintf("%d", opts.AlbumOffset)},
+		"songCount":    {fmt.Sprintf("%d", opts.SongCount)},
+		"songOffset":   {fmt.Sprintf("%d", opts.SongOffset)},
 	}
 
-	resp, err := c.HttpClient.Do(req)
-	if err != nil {
-		return err
+	var result struct {
+		SearchResult3 SearchResult3 `json:"searchResult3"`
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("unexpected status: %d, response: %s", resp.StatusCode, string(body))
+	if err := c.request(ctx, "search3", params, &result); err != nil {
+		return nil, err
 	}
+	return &result.SearchResult3, nil
+}
 
-	// Consume body for connection reuse
-	io.Copy(io.Discard, resp.Body)
-	return nil
+// StreamOptions selects the stream's transcoding. Format "raw" asks for the
+// original file; empty fields are left to the server.
+type StreamOptions struct {
+	Format     string
+	MaxBitRate int // kbps
+	TimeOffset int // seconds into the song to start a transcoded stream at
 }
 
-func (c *Client) SearchSongs(query string) ([]Song, error) {
-	params, err := c.buildParams(map[string]string{
-		"query":     query,
-		"songCount": "10",
-	})
-	if err != nil {
-		return nil, fmt.Errorf("build params: %w", err)
+func (c *Client) GetPlayURL(songID string, opts StreamOptions) string {
+	extra := map[string]string{
+		"id": songID,
 	}
-
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/rest/search3.view?%s", c.BaseURL, params.Encode()), nil)
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+	if opts.Format != "" {
+		extra["format"] = opts.Format
 	}
-
-	resp, err := c.HttpClient.Do(req)
-	if err != nil {
-		return nil, err
+	if opts.MaxBitRate > 0 {
+		extra["maxBitRate"] = fmt.Sprintf("%d", opts.MaxBitRate)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status: %d, response: %s", resp.StatusCode, string(body))
+	if opts.TimeOffset > 0 {
+		extra["timeOffset"] = fmt.Sprintf("%d", opts.TimeOffset)
 	}
-
-	var result struct {
-		SubsonicResponse struct {
-			SearchResult3 struct {
-				Songs []Song `json:"song"`
-			} `json:"searchResult3"`
-		} `json:"subsonic-response"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
-	}
-
-	return result.SubsonicResponse.SearchResult3.Songs, nil
-}
-
-func (c *Client) GetPlayURL(songID string) string {
-	params, err := c.buildParams(map[string]string{
-		"id":     songID,
-		"format": "mp3",
-	})
+	params, err := c.buildParams(extra)
 	if err != nil {
 		log.Printf("GetPlayURL buildParams error: %v", err)
 		return ""
@@ -147,85 +89,51 @@ func (c *Client) GetPlayURL(songID string) string {
 	return fmt.Sprintf("%s/rest/stream.view?%s", c.BaseURL, params.Encode())
 }
 
-func (c *Client) GetAlbumList2(albumType string, size, offset int) ([]AlbumID3, error) {
+// AlbumListFilter carries the extra parameters of the byYear and byGenre
+// album list types.
+type AlbumListFilter struct {
+	FromYear int
+	ToYear   int
+	Genre    string
+}
+
+func (c *Client) GetAlbumList2(ctx context.Context, albumType string, size, offset int, filter AlbumListFilter) ([]AlbumID3, error) {
 	if size <= 0 {
 		size = 20
 	}
-	params, err := c.buildParams(map[string]string{
-		"type":   albumType,
-		"size":   fmt.Sprintf("%d", size),
-		"offset": fmt.Sprintf("%d", offset),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("build params: %w", err)
-	}
-
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/rest/getAlbumList2?%s", c.BaseURL, params.Encode()), nil)
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+	params := url.Values{
+		"type":   {albumType},
+		"size":   {fmt.Sprintf("%d", size)},
+		"offset": {fmt.Sprintf("%d", offset)},
 	}
-
-	resp, err := c.HttpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status: %d, response: %s", resp.StatusCode, string(body))
+	switch albumType {
+	case "byYear":
+		params.Set("fromYear", fmt.Sprintf("%d", filter.FromYear))
+		params.Set("toYear", fmt.Sprintf("%d", filter.ToYear))
+	case "byGenre":
+		params.Set("genre", filter.Genre)
 	}
 
 	var result struct {
-		SubsonicResponse struct {
-			AlbumList2 struct {
-				Albums []AlbumID3 `json:"album"`
-			} `json:"albumList2"`
-		} `json:"subsonic-response"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
-	}
-
-	return result.SubsonicResponse.AlbumList2.Albums, nil
-}
-
-func (c *Client) GetAlbum(albumID string) ([]Song, error) {
-	params, err := c.buildParams(map[string]string{
-		"id": albumID,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("build params: %w", err)
+		AlbumList2 struct {
+			Albums []AlbumID3 `json:"album"`
+		} `json:"albumList2"`
 	}
-
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/rest/getAlbum?%s", c.BaseURL, params.Encode()), nil)
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-
-	resp, err := c.HttpClient.Do(req)
-	if err != nil {
+	if err := c.request(ctx, "getAlbumList2", params, &result); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status: %d, response: %s", resp.StatusCode, string(body))
-	}
+	return result.AlbumList2.Albums, nil
+}
 
+// GetAlbum returns an album with its songs.
+func (c *Client) GetAlbum(ctx context.Context, albumID string) (*AlbumID3, error) {
 	var result struct {
-		SubsonicResponse struct {
-			Album struct {
-				Songs []Song `json:"song"`
-			} `json:"album"`
-		} `json:"subsonic-response"`
+		Album AlbumID3 `json:"album"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+	if err := c.request(ctx, "getAlbum", url.Values{"id": {albumID}}, &result); err != nil {
+		return nil, err
 	}
-
-	return result.SubsonicResponse.Album.Songs, nil
+	return &result.Album, nil
 }
 
 func (c *Client) GetCoverArtURL(coverArtID string) string {
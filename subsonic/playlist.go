@@ -0,0 +1,98 @@
+package subsonic
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type Playlist struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Comment   string    `json:"comment"`
+	Owner     string    `json:"owner"`
+	Public    bool      `json:"public"`
+	SongCount int       `json:"songCount"`
+	Duration  int       `json:"duration"` // in seconds
+	Created   time.Time `json:"created"`
+	Changed   time.Time `json:"changed"`
+	CoverArt  string    `json:"coverArt"`
+	Entries   []Song    `json:"entry"` // only filled by getPlaylist/createPlaylist
+}
+
+// PlaylistUpdate describes the changes sent by UpdatePlaylist. Empty fields
+// are left untouched on the server.
+type PlaylistUpdate struct {
+	Name            string
+	Comment         string
+	Public          *bool
+	SongIDsToAdd    []string
+	IndexesToRemove []int
+}
+
+func (c *Client) GetPlaylists(ctx context.Context) ([]Playlist, error) {
+	var result struct {
+		Playlists struct {
+			Playlists []Playlist `json:"playlist"`
+		} `json:"playlists"`
+	}
+	if err := c.request(ctx, "getPlaylists", nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Playlists.Playlists, nil
+}
+
+func (c *Client) GetPlaylist(ctx context.Context, id string) (*Playlist, error) {
+	return c.fetchPlaylist(ctx, "getPlaylist", url.Values{"id": {id}})
+}
+
+// CreatePlaylist creates a new playlist holding songIDs in order.
+func (c *Client) CreatePlaylist(ctx context.Context, name string, songIDs []string) (*Playlist, error) {
+	return c.fetchPlaylist(ctx, "createPlaylist", url.Values{"name": {name}, "songId": songIDs})
+}
+
+// ReplacePlaylistSongs overwrites the tracks of an existing playlist with
+// songIDs in the given order. This is how playlists are reordered, as
+// updatePlaylist can only append and remove.
+func (c *Client) ReplacePlaylistSongs(ctx context.Context, id string, songIDs []string) error {
+	_, err := c.fetchPlaylist(ctx, "createPlaylist", url.Values{"playlistId": {id}, "songId": songIDs})
+	return err
+}
+
+func (c *Client) UpdatePlaylist(ctx context.Context, id string, update PlaylistUpdate) error {
+	params := url.Values{"playlistId": {id}}
+	if update.Name != "" {
+		params.Set("name", update.Name)
+	}
+	if update.Comment != "" {
+		params.Set("comment", update.Comment)
+	}
+	if update.Public != nil {
+		params.Set("public", strconv.FormatBool(*update.Public))
+	}
+	for _, songID := range update.SongIDsToAdd {
+		params.Add("songIdToAdd", songID)
+	}
+	for _, index := range update.IndexesToRemove {
+		params.Add("songIndexToRemove", strconv.Itoa(index))
+	}
+	return c.request(ctx, "updatePlaylist", params, nil)
+}
+
+func (c *Client) DeletePlaylist(ctx context.Context, id string) error {
+	return c.request(ctx, "deletePlaylist", url.Values{"id": {id}}, nil)
+}
+
+// fetchPlaylist calls an endpoint that answers with a single playlist
+// element. Older servers return an empty body for createPlaylist, in which
+// case the playlist is nil.
+func (c *Client) fetchPlaylist(ctx context.Context, endpoint string, params url.Values) (*Playlist, error) {
+	var result struct {
+		Playlist *Playlist `json:"playlist"`
+	}
+	if err := c.request(ctx, endpoint, params, &result); err != nil {
+		return nil, err
+	}
+	return result.Playlist, nil
+}
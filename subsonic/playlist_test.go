@@ -0,0 +1,41 @@
+package subsonic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReplacePlaylistSongsPostsForm(t *testing.T) {
+	songIDs := make([]string, 2000)
+	for i := range songIDs {
+		songIDs[i] = fmt.Sprintf("song-%d", i)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.RawQuery != "" {
+			t.Errorf("got %s with query %q, want a POST without query", r.Method, r.URL.RawQuery)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if got := r.PostForm["songId"]; len(got) != len(songIDs) || got[1999] != "song-1999" {
+			t.Errorf("got %d song IDs, want %d", len(got), len(songIDs))
+		}
+		if r.PostForm.Get("playlistId") != "pl-1" || r.PostForm.Get("u") != "user" {
+			t.Errorf("form lacks the playlist or auth parameters: %v", r.PostForm)
+		}
+		w.Write([]byte(`{"subsonic-response":{"status":"ok","version":"1.16.1","playlist":{"id":"pl-1"}}}`))
+	}))
+	defer srv.Close()
+
+	c := Init(srv.URL, "user", "pass", "test", "1.16.1", 20, 5*time.Second)
+	if err := c.ReplacePlaylistSongs(context.Background(), "pl-1", songIDs); err != nil {
+		t.Fatalf("ReplacePlaylistSongs: %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package subsonic
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// PlayQueue is the queue a user saved on the server, shared between clients
+type PlayQueue struct {
+	Entries   []Song    `json:"entry"`
+	Current   string    `json:"current"`
+	Position  int64     `json:"position"` // in milliseconds
+	Username  string    `json:"username"`
+	Changed   time.Time `json:"changed"`
+	ChangedBy string    `json:"changedBy"`
+}
+
+// SavePlayQueue replaces the user's saved queue. position is the playback
+// position within current, in milliseconds.
+func (c *Client) SavePlayQueue(ctx context.Context, songIDs []string, current string, position int64) error {
+	params := url.Values{"id": songIDs}
+	if current != "" {
+		params.Set("current", current)
+		params.Set("position", strconv.FormatInt(position, 10))
+	}
+	return c.request(ctx, "savePlayQueue", params, nil)
+}
+
+// GetPlayQueue returns the user's saved queue. The queue is empty when none
+// has been saved.
+func (c *Client) GetPlayQueue(ctx context.Context) (*PlayQueue, error) {
+	var result struct {
+		PlayQueue PlayQueue `json:"playQueue"`
+	}
+	if err := c.request(ctx, "getPlayQueue", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result.PlayQueue, nil
+}
@@ -0,0 +1,86 @@
+package subsonic
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// PodcastEpisode is a podcast episode. The embedded song's ID is the episode
+// ID; StreamID is what stream and scrobble calls take, and is only set once
+// the episode is downloaded.
+type PodcastEpisode struct {
+	Song
+	StreamID    string    `json:"streamId"`
+	ChannelID   string    `json:"channelId"`
+	Description string    `json:"description"`
+	Status      string    `json:"status"` // new, downloading, completed, error, deleted or skipped
+	PublishDate time.Time `json:"publishDate"`
+}
+
+type PodcastChannel struct {
+	ID           string           `json:"id"`
+	URL          string           `json:"url"`
+	Title        string           `json:"title"`
+	Description  string           `json:"description"`
+	CoverArt     string           `json:"coverArt"`
+	Status       string           `json:"status"`
+	ErrorMessage string           `json:"errorMessage"`
+	Episodes     []PodcastEpisode `json:"episode"`
+}
+
+// GetPodcasts returns the subscribed channels. With channelID set only that
+// channel is returned; episodes are included when includeEpisodes is true.
+func (c *Client) GetPodcasts(ctx context.Context, channelID string, includeEpisodes bool) ([]PodcastChannel, error) {
+	params := url.Values{
+		"includeEpisodes": {fmt.Sprintf("%t", includeEpisodes)},
+	}
+	if channelID != "" {
+		params.Set("id", channelID)
+	}
+
+	var result struct {
+		Podcasts struct {
+			Channels []PodcastChannel `json:"channel"`
+		} `json:"podcasts"`
+	}
+	if err := c.request(ctx, "getPodcasts", params, &result); err != nil {
+		return nil, err
+	}
+	return result.Podcasts.Channels, nil
+}
+
+// GetNewestPodcasts returns the most recently published episodes across all
+// channels
+func (c *Client) GetNewestPodcasts(ctx context.Context, count int) ([]PodcastEpisode, error) {
+	var result struct {
+		NewestPodcasts struct {
+			Episodes []PodcastEpisode `json:"episode"`
+		} `json:"newestPodcasts"`
+	}
+	if err := c.request(ctx, "getNewestPodcasts", url.Values{"count": {fmt.Sprintf("%d", count)}}, &result); err != nil {
+		return nil, err
+	}
+	return result.NewestPodcasts.Episodes, nil
+}
+
+// RefreshPodcasts asks the server to check every channel for new episodes.
+// The check runs in the background on the server.
+func (c *Client) RefreshPodcasts(ctx context.Context) error {
+	return c.request(ctx, "refreshPodcasts", nil, nil)
+}
+
+func (c *Client) CreatePodcastChannel(ctx context.Context, feedURL string) error {
+	return c.request(ctx, "createPodcastChannel", url.Values{"url": {feedURL}}, nil)
+}
+
+func (c *Client) DeletePodcastChannel(ctx context.Context, id string) error {
+	return c.request(ctx, "deletePodcastChannel", url.Values{"id": {id}}, nil)
+}
+
+// DownloadPodcastEpisode asks the server to download an episode so it can be
+// streamed
+func (c *Client) DownloadPodcastEpisode(ctx context.Context, id string) error {
+	return c.request(ctx, "downloadPodcastEpisode", url.Values{"id": {id}}, nil)
+}
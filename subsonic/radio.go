@@ -0,0 +1,53 @@
+package subsonic
+
+import (
+	"context"
+	"net/url"
+)
+
+type InternetRadioStation struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	StreamURL   string `json:"streamUrl"`
+	HomePageURL string `json:"homePageUrl"`
+}
+
+func (c *Client) GetInternetRadioStations(ctx context.Context) ([]InternetRadioStation, error) {
+	var result struct {
+		InternetRadioStations struct {
+			Stations []InternetRadioStation `json:"internetRadioStation"`
+		} `json:"internetRadioStations"`
+	}
+	if err := c.request(ctx, "getInternetRadioStations", nil, &result); err != nil {
+		return nil, err
+	}
+	return result.InternetRadioStations.Stations, nil
+}
+
+// CreateInternetRadioStation adds a station. Only admins may change stations.
+func (c *Client) CreateInternetRadioStation(ctx context.Context, streamURL, name, homePageURL string) error {
+	params := url.Values{
+		"streamUrl": {streamURL},
+		"name":      {name},
+	}
+	if homePageURL != "" {
+		params.Set("homepageUrl", homePageURL)
+	}
+	return c.request(ctx, "createInternetRadioStation", params, nil)
+}
+
+func (c *Client) UpdateInternetRadioStation(ctx context.Context, id, streamURL, name, homePageURL string) error {
+	params := url.Values{
+		"id":        {id},
+		"streamUrl": {streamURL},
+		"name":      {name},
+	}
+	if homePageURL != "" {
+		params.Set("homepageUrl", homePageURL)
+	}
+	return c.request(ctx, "updateInternetRadioStation", params, nil)
+}
+
+func (c *Client) DeleteInternetRadioStation(ctx context.Context, id string) error {
+	return c.request(ctx, "deleteInternetRadioStation", url.Values{"id": {id}}, nil)
+}
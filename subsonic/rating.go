@@ -0,0 +1,16 @@
+package subsonic
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// SetRating sets the user's rating (1-5) for a song, album or artist. A rating
+// of 0 removes it.
+func (c *Client) SetRating(ctx context.Context, id string, rating int) error {
+	return c.request(ctx, "setRating", url.Values{
+		"id":     {id},
+		"rating": {strconv.Itoa(rating)},
+	}, nil)
+}
@@ -0,0 +1,150 @@
+package subsonic
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Error codes the Subsonic API reports in a failed response
+const (
+	ErrGeneric              = 0
+	ErrMissingParameter     = 10
+	ErrClientTooOld         = 20
+	ErrServerTooOld         = 30
+	ErrWrongCredentials     = 40
+	ErrTokenAuthUnsupported = 41
+	ErrAuthUnsupported      = 42 // OpenSubsonic: the server does not support the authentication method
+	ErrConflictingAuth      = 43 // OpenSubsonic: more than one authentication method was sent
+	ErrInvalidAPIKey        = 44 // OpenSubsonic
+	ErrNotAuthorized        = 50
+	ErrTrialExpired         = 60
+	ErrNotFound             = 70
+)
+
+// Error is the error of a subsonic-response with status "failed". Callers can
+// inspect Code through errors.As.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("subsonic error %d: %s", e.Code, e.Message)
+}
+
+// request calls endpoint with params on top of the authentication parameters
+// and decodes the contents of the subsonic-response envelope into out. out may
+// be nil for endpoints that only report a status. A failed response is
+// returned as *Error; cancelling ctx aborts the call.
+//
+// Idempotent calls are retried with jittered exponential backoff while the
+// server is unreachable. Calls fail fast with ErrUnavailable while the circuit
+// breaker is open, except ping, which monitors the connection and so always
+// goes through.
+func (c *Client) request(ctx context.Context, endpoint string, params url.Values, out interface{}) error {
+	if endpoint != "ping" {
+		if err := c.breaker.allow(); err != nil {
+			return err
+		}
+	}
+
+	attempts := 1
+	if !nonIdempotent[endpoint] {
+		attempts += maxRetries
+	}
+
+	var body []byte
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, backoff(attempt)); err != nil {
+				break
+			}
+		}
+		body, err = c.fetch(ctx, endpoint, params)
+		if !IsUnavailable(err) || ctx.Err() != nil {
+			break
+		}
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		c.breaker.release()
+		if err == nil {
+			err = ctx.Err()
+		}
+		return err
+	case IsUnavailable(err):
+		c.breaker.failure()
+		return err
+	}
+	c.breaker.success()
+	if err != nil {
+		return err
+	}
+	return decodeResponse(body, out)
+}
+
+// postForm lists the endpoints whose parameters are sent as a form-encoded
+// POST body instead of the query string. They can carry every song of a
+// playlist, which quickly outgrows the URL length servers and proxies accept.
+var postForm = map[string]bool{
+	"createPlaylist": true,
+	"updatePlaylist": true,
+}
+
+// fetch performs one call of endpoint and returns the response body
+func (c *Client) fetch(ctx context.Context, endpoint string, params url.Values) ([]byte, error) {
+	query, err := c.buildParams(map[string]string{})
+	if err != nil {
+		return nil, fmt.Errorf("build params: %w", err)
+	}
+	for k, values := range params {
+		for _, v := range values {
+			query.Add(k, v)
+		}
+	}
+	if folder := c.MusicFolder(); folder != "" && folderScoped[endpoint] {
+		query.Set("musicFolderId", folder)
+	}
+
+	var req *http.Request
+	if postForm[endpoint] {
+		req, err = http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/rest/%s.view", c.BaseURL, endpoint), strings.NewReader(query.Encode()))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	} else {
+		req, err = http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/rest/%s.view?%s", c.BaseURL, endpoint, query.Encode()), nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	for name, values := range c.Headers {
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, &statusError{code: resp.StatusCode, body: string(body)}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	return body, nil
+}
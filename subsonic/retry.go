@@ -0,0 +1,161 @@
+package subsonic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	maxRetries       = 3                // extra attempts for idempotent calls
+	breakerThreshold = 3                // failed calls in a row that open the breaker
+	breakerCooldown  = 15 * time.Second // how long an open breaker rejects calls
+)
+
+// The backoff bounds are variables so tests can shorten them
+var (
+	retryBaseDelay = 500 * time.Millisecond // first backoff, doubled per attempt
+	retryMaxDelay  = 8 * time.Second
+)
+
+// ErrUnavailable is returned without contacting the server while the circuit
+// breaker is open after repeated connection failures
+var ErrUnavailable = errors.New("server unavailable")
+
+// nonIdempotent lists the endpoints that are never retried, since sending
+// them twice would record a play twice or duplicate a playlist entry,
+// subscription or station
+var nonIdempotent = map[string]bool{
+	"scrobble":                   true,
+	"createPlaylist":             true,
+	"updatePlaylist":             true,
+	"createPodcastChannel":       true,
+	"createInternetRadioStation": true,
+}
+
+// statusError is an HTTP response other than 200 OK
+type statusError struct {
+	code int
+	body string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status: %d, response: %s", e.code, e.body)
+}
+
+// IsUnavailable reports whether err means the server could not be reached or
+// could not answer, as opposed to a request it rejected or a cancelled call.
+// Such calls are worth repeating once the server is back.
+func IsUnavailable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return false
+	}
+	var status *statusError
+	if errors.As(err, &status) {
+		return status.code >= 500 || status.code == http.StatusTooManyRequests
+	}
+	return true
+}
+
+// backoff returns the delay before retry number attempt (starting at 1):
+// a random duration up to the exponentially growing cap
+func backoff(attempt int) time.Duration {
+	limit := retryBaseDelay << (attempt - 1)
+	if limit > retryMaxDelay || limit <= 0 {
+		limit = retryMaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(limit)))
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// breaker stops calls to a server that keeps failing. After threshold
+// failures in a row it opens and rejects calls for cooldown; then one call is
+// let through, and its outcome closes or reopens the breaker.
+type breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	open      bool
+	openedAt  time.Time
+	probing   bool
+	onChange  func(available bool)
+}
+
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{threshold: threshold, cooldown: cooldown}
+}
+
+func (b *breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.open {
+		return nil
+	}
+	if b.probing || time.Since(b.openedAt) < b.cooldown {
+		return ErrUnavailable
+	}
+	b.probing = true
+	return nil
+}
+
+func (b *breaker) success() {
+	b.mu.Lock()
+	changed := b.open
+	b.failures, b.open, b.probing = 0, false, false
+	onChange := b.onChange
+	b.mu.Unlock()
+	if changed && onChange != nil {
+		onChange(true)
+	}
+}
+
+// release lets another call probe the server after the probing call was
+// cancelled before it got an answer
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+func (b *breaker) failure() {
+	b.mu.Lock()
+	b.failures++
+	changed := false
+	if b.probing || (!b.open && b.failures >= b.threshold) {
+		changed = !b.open
+		b.open, b.openedAt, b.probing = true, time.Now(), false
+	}
+	onChange := b.onChange
+	b.mu.Unlock()
+	if changed && onChange != nil {
+		onChange(false)
+	}
+}
+
+// OnAvailabilityChange registers fn to be called when the server becomes
+// unreachable (the breaker opens) or reachable again
+func (c *Client) OnAvailabilityChange(fn func(available bool)) {
+	c.breaker.mu.Lock()
+	defer c.breaker.mu.Unlock()
+	c.breaker.onChange = fn
+}
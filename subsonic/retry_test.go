@@ -0,0 +1,231 @@
+package subsonic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	okBody     = `{"subsonic-response":{"status":"ok","version":"1.16.1"}}`
+	failedBody = `{"subsonic-response":{"status":"failed","version":"1.16.1","error":{"code":70,"message":"not found"}}}`
+)
+
+// stubServer answers every call with the current status and body, optionally
+// holding the response until the block channel is closed
+type stubServer struct {
+	mu     sync.Mutex
+	status int
+	body   string
+	block  chan struct{}
+	hits   atomic.Int32
+}
+
+func (s *stubServer) set(status int, body string, block chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status, s.body, s.block = status, body, block
+}
+
+func (s *stubServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.hits.Add(1)
+	s.mu.Lock()
+	status, body, block := s.status, s.body, s.block
+	s.mu.Unlock()
+	if block != nil {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+			return
+		}
+	}
+	w.WriteHeader(status)
+	w.Write([]byte(body))
+}
+
+// stubClient returns a client talking to a stubServer, with backoff delays
+// short enough to retry without slowing the tests down
+func stubClient(t *testing.T, status int, body string) (*Client, *stubServer) {
+	t.Helper()
+	base, max := retryBaseDelay, retryMaxDelay
+	retryBaseDelay, retryMaxDelay = time.Millisecond, time.Millisecond
+	t.Cleanup(func() { retryBaseDelay, retryMaxDelay = base, max })
+
+	stub := &stubServer{status: status, body: body}
+	srv := httptest.NewServer(stub)
+	t.Cleanup(srv.Close)
+	return Init(srv.URL, "user", "pass", "test", "1.16.1", 20, 5*time.Second), stub
+}
+
+type retryCase struct {
+	name     string
+	endpoint string
+	status   int
+	body     string
+	attempts int32 // calls that reach the server
+	down     bool  // whether the error reports the server unavailable
+}
+
+func TestRequestRetries(t *testing.T) {
+	tests := []retryCase{
+		{"ok", "getGenres", http.StatusOK, okBody, 1, false},
+		{"server error", "getGenres", http.StatusInternalServerError, "", 1 + maxRetries, true},
+		{"bad gateway", "getGenres", http.StatusBadGateway, "", 1 + maxRetries, true},
+		{"rate limited", "getGenres", http.StatusTooManyRequests, "", 1 + maxRetries, true},
+		{"not found", "getGenres", http.StatusNotFound, "", 1, false},
+		{"unauthorized", "getGenres", http.StatusUnauthorized, "", 1, false},
+		{"api error", "getGenres", http.StatusOK, failedBody, 1, false},
+	}
+	for endpoint := range nonIdempotent {
+		tests = append(tests, retryCase{"non-idempotent " + endpoint, endpoint, http.StatusServiceUnavailable, "", 1, true})
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, stub := stubClient(t, tt.status, tt.body)
+			err := c.request(context.Background(), tt.endpoint, nil, nil)
+			if got := stub.hits.Load(); got != tt.attempts {
+				t.Errorf("attempts = %d, want %d", got, tt.attempts)
+			}
+			if got := IsUnavailable(err); got != tt.down {
+				t.Errorf("IsUnavailable(%v) = %v, want %v", err, got, tt.down)
+			}
+			var apiErr *Error
+			if tt.body == failedBody && !errors.As(err, &apiErr) {
+				t.Errorf("err = %v, want *Error", err)
+			}
+		})
+	}
+}
+
+func TestIsUnavailable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{context.Canceled, false},
+		{&Error{Code: ErrWrongCredentials}, false},
+		{&statusError{code: http.StatusForbidden}, false},
+		{&statusError{code: http.StatusServiceUnavailable}, true},
+		{&statusError{code: http.StatusTooManyRequests}, true},
+		{ErrUnavailable, true},
+		{errors.New("connection refused"), true},
+	}
+	for _, tt := range tests {
+		if got := IsUnavailable(tt.err); got != tt.want {
+			t.Errorf("IsUnavailable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestBreakerOpensAndProbes(t *testing.T) {
+	c, stub := stubClient(t, http.StatusServiceUnavailable, "")
+	c.breaker.cooldown = 20 * time.Millisecond
+	var changes []bool
+	var changesMu sync.Mutex
+	c.OnAvailabilityChange(func(available bool) {
+		changesMu.Lock()
+		defer changesMu.Unlock()
+		changes = append(changes, available)
+	})
+	ctx := context.Background()
+
+	for i := 0; i < breakerThreshold; i++ {
+		if err := c.request(ctx, "getGenres", nil, nil); errors.Is(err, ErrUnavailable) {
+			t.Fatalf("call %d rejected before the breaker should open", i+1)
+		}
+	}
+	hits := stub.hits.Load()
+	if err := c.request(ctx, "getGenres", nil, nil); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("err = %v with the breaker open, want ErrUnavailable", err)
+	}
+	if stub.hits.Load() != hits {
+		t.Error("an open breaker let a call reach the server")
+	}
+
+	// after the cooldown one probe goes through while others are rejected
+	time.Sleep(c.breaker.cooldown)
+	block := make(chan struct{})
+	stub.set(http.StatusOK, okBody, block)
+	probe := make(chan error)
+	go func() { probe <- c.request(ctx, "getGenres", nil, nil) }()
+	for stub.hits.Load() == hits {
+		time.Sleep(time.Millisecond)
+	}
+	if err := c.request(ctx, "getGenres", nil, nil); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("err = %v during the probe, want ErrUnavailable", err)
+	}
+	close(block)
+	if err := <-probe; err != nil {
+		t.Fatalf("probe: %v", err)
+	}
+	if got := stub.hits.Load() - hits; got != 1 {
+		t.Errorf("%d calls reached the server after the cooldown, want 1", got)
+	}
+	if err := c.request(ctx, "getGenres", nil, nil); err != nil {
+		t.Errorf("err = %v after a successful probe, want nil", err)
+	}
+
+	changesMu.Lock()
+	defer changesMu.Unlock()
+	if len(changes) != 2 || changes[0] || !changes[1] {
+		t.Errorf("availability changes = %v, want [false true]", changes)
+	}
+}
+
+func TestBreakerFailedProbeReopens(t *testing.T) {
+	c, _ := stubClient(t, http.StatusServiceUnavailable, "")
+	c.breaker.cooldown = 20 * time.Millisecond
+	var changes atomic.Int32
+	c.OnAvailabilityChange(func(bool) { changes.Add(1) })
+	ctx := context.Background()
+
+	for i := 0; i < breakerThreshold; i++ {
+		c.request(ctx, "getGenres", nil, nil)
+	}
+	time.Sleep(c.breaker.cooldown)
+	if err := c.request(ctx, "getGenres", nil, nil); errors.Is(err, ErrUnavailable) || err == nil {
+		t.Fatalf("probe err = %v, want the server's failure", err)
+	}
+	if err := c.request(ctx, "getGenres", nil, nil); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("err = %v after a failed probe, want ErrUnavailable", err)
+	}
+	if got := changes.Load(); got != 1 {
+		t.Errorf("availability changed %d times, want 1", got)
+	}
+}
+
+func TestBreakerCancelledProbeReleases(t *testing.T) {
+	c, stub := stubClient(t, http.StatusServiceUnavailable, "")
+	c.breaker.cooldown = 20 * time.Millisecond
+	for i := 0; i < breakerThreshold; i++ {
+		c.request(context.Background(), "getGenres", nil, nil)
+	}
+	time.Sleep(c.breaker.cooldown)
+
+	block := make(chan struct{})
+	defer close(block)
+	stub.set(http.StatusOK, okBody, block)
+	ctx, cancel := context.WithCancel(context.Background())
+	hits := stub.hits.Load()
+	probe := make(chan error)
+	go func() { probe <- c.request(ctx, "getGenres", nil, nil) }()
+	for stub.hits.Load() == hits {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-probe; !errors.Is(err, context.Canceled) {
+		t.Fatalf("probe err = %v, want context.Canceled", err)
+	}
+
+	stub.set(http.StatusOK, okBody, nil)
+	if err := c.request(context.Background(), "getGenres", nil, nil); err != nil {
+		t.Errorf("err = %v after a cancelled probe, want another probe to go through", err)
+	}
+}
@@ -0,0 +1,32 @@
+package subsonic
+
+import (
+	"context"
+)
+
+// ScanStatus reports a library scan. Count is the number of items scanned so
+// far, or in total once the scan is done.
+type ScanStatus struct {
+	Scanning bool `json:"scanning"`
+	Count    int  `json:"count"`
+}
+
+// StartScan asks the server to rescan its media folders. Asking while a scan
+// runs does not start another.
+func (c *Client) StartScan(ctx context.Context) (*ScanStatus, error) {
+	return c.scanStatus(ctx, "startScan")
+}
+
+func (c *Client) GetScanStatus(ctx context.Context) (*ScanStatus, error) {
+	return c.scanStatus(ctx, "getScanStatus")
+}
+
+func (c *Client) scanStatus(ctx context.Context, endpoint string) (*ScanStatus, error) {
+	var result struct {
+		ScanStatus ScanStatus `json:"scanStatus"`
+	}
+	if err := c.request(ctx, endpoint, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result.ScanStatus, nil
+}
@@ -0,0 +1,19 @@
+package subsonic
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Scrobble registers a play with the server. With submission=false the server
+// only updates its "now playing" list; with submission=true it records the
+// play in the user's history and play counts.
+func (c *Client) Scrobble(ctx context.Context, songID string, playedAt time.Time, submission bool) error {
+	return c.request(ctx, "scrobble", url.Values{
+		"id":         {songID},
+		"time":       {strconv.FormatInt(playedAt.UnixMilli(), 10)},
+		"submission": {strconv.FormatBool(submission)},
+	}, nil)
+}
@@ -0,0 +1,42 @@
+package subsonic
+
+import (
+	"context"
+)
+
+// ServerInfo is what ping reports about the server. Type, ServerVersion and
+// OpenSubsonic are only set by OpenSubsonic servers.
+type ServerInfo struct {
+	Version       string `json:"version"` // Subsonic API version
+	Type          string `json:"type"`    // server software, e.g. "navidrome"
+	ServerVersion string `json:"serverVersion"`
+	OpenSubsonic  bool   `json:"openSubsonic"`
+}
+
+// Extension is an OpenSubsonic extension and the versions of it the server
+// implements
+type Extension struct {
+	Name     string `json:"name"`
+	Versions []int  `json:"versions"`
+}
+
+// GetServerInfo pings the server, which also verifies the credentials
+func (c *Client) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	var info ServerInfo
+	if err := c.request(ctx, "ping", nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// GetOpenSubsonicExtensions lists the extensions the server supports. Only
+// servers whose ping sets openSubsonic implement it.
+func (c *Client) GetOpenSubsonicExtensions(ctx context.Context) ([]Extension, error) {
+	var result struct {
+		Extensions []Extension `json:"openSubsonicExtensions"`
+	}
+	if err := c.request(ctx, "getOpenSubsonicExtensions", nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Extensions, nil
+}
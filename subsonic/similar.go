@@ -0,0 +1,44 @@
+package subsonic
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// GetSimilarSongs2 returns songs similar to a song, album or artist ID. For an
+// artist the result mixes in tracks by similar artists.
+func (c *Client) GetSimilarSongs2(ctx context.Context, id string, count int) ([]Song, error) {
+	params := url.Values{
+		"id":    {id},
+		"count": {fmt.Sprintf("%d", count)},
+	}
+
+	var result struct {
+		SimilarSongs2 struct {
+			Songs []Song `json:"song"`
+		} `json:"similarSongs2"`
+	}
+	if err := c.request(ctx, "getSimilarSongs2", params, &result); err != nil {
+		return nil, err
+	}
+	return result.SimilarSongs2.Songs, nil
+}
+
+// GetTopSongs returns the most popular songs of an artist, looked up by name
+func (c *Client) GetTopSongs(ctx context.Context, artist string, count int) ([]Song, error) {
+	params := url.Values{
+		"artist": {artist},
+		"count":  {fmt.Sprintf("%d", count)},
+	}
+
+	var result struct {
+		TopSongs struct {
+			Songs []Song `json:"song"`
+		} `json:"topSongs"`
+	}
+	if err := c.request(ctx, "getTopSongs", params, &result); err != nil {
+		return nil, err
+	}
+	return result.TopSongs.Songs, nil
+}
@@ -0,0 +1,41 @@
+package subsonic
+
+import (
+	"context"
+	"net/url"
+)
+
+// Parameter names used by star/unstar to select the kind of item.
+const (
+	StarSong   = "id"
+	StarAlbum  = "albumId"
+	StarArtist = "artistId"
+)
+
+// Starred2 holds everything the user has starred, organized by ID3 tags.
+type Starred2 struct {
+	Artists []ArtistID3 `json:"artist"`
+	Albums  []AlbumID3  `json:"album"`
+	Songs   []Song      `json:"song"`
+}
+
+// Star marks a song, album or artist as favorite. kind is one of StarSong,
+// StarAlbum or StarArtist.
+func (c *Client) Star(ctx context.Context, kind, id string) error {
+	return c.request(ctx, "star", url.Values{kind: {id}}, nil)
+}
+
+// Unstar removes the favorite mark from a song, album or artist.
+func (c *Client) Unstar(ctx context.Context, kind, id string) error {
+	return c.request(ctx, "unstar", url.Values{kind: {id}}, nil)
+}
+
+func (c *Client) GetStarred2(ctx context.Context) (*Starred2, error) {
+	var result struct {
+		Starred2 Starred2 `json:"starred2"`
+	}
+	if err := c.request(ctx, "getStarred2", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result.Starred2, nil
+}
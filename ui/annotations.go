@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yhkl-dev/NaviCLI/domain"
+)
+
+// toggleStar stars or unstars the song on the selected row.
+func (a *App) toggleStar() {
+	index := a.selectedSongIndex()
+	if index < 0 {
+		return
+	}
+	a.songsMu.RLock()
+	song := a.totalSongs[index]
+	a.songsMu.RUnlock()
+
+	starred := song.Starred == nil
+	go func() {
+		if err := a.library.SetStarred(a.ctx, domain.ItemSong, song.ID, starred); err != nil {
+			a.tviewApp.QueueUpdateDraw(func() {
+				a.statusBar.SetText("[red]Failed to update star: " + errorText(err))
+			})
+			return
+		}
+
+		var starredAt *time.Time
+		if starred {
+			now := time.Now()
+			starredAt = &now
+		}
+		a.updateSongs(song.ID, func(s *domain.Song) { s.Starred = starredAt })
+	}()
+}
+
+// rateSelected sets the rating of the song on the selected row. A rating of 0
+// clears it.
+func (a *App) rateSelected(rating int) {
+	index := a.selectedSongIndex()
+	if index < 0 {
+		return
+	}
+	a.songsMu.RLock()
+	song := a.totalSongs[index]
+	a.songsMu.RUnlock()
+
+	go func() {
+		if err := a.library.SetRating(a.ctx, song.ID, rating); err != nil {
+			a.tviewApp.QueueUpdateDraw(func() {
+				a.statusBar.SetText(fmt.Sprintf("[red]Failed to rate %s: %s", song.Title, errorText(err)))
+			})
+			return
+		}
+		a.updateSongs(song.ID, func(s *domain.Song) { s.UserRating = rating })
+	}()
+}
+
+// updateSongs applies fn to every loaded copy of the song and redraws the
+// table. It must not be called from the UI goroutine.
+func (a *App) updateSongs(id string, fn func(*domain.Song)) {
+	a.songsMu.Lock()
+	for _, songs := range [][]domain.Song{a.totalSongs, a.originalSongs} {
+		for i := range songs {
+			if songs[i].ID == id {
+				fn(&songs[i])
+			}
+		}
+	}
+	a.songsMu.Unlock()
+
+	a.tviewApp.QueueUpdateDraw(func() {
+		a.renderSongTable()
+	})
+}
+
+// withMinRating drops songs rated below the configured minimum. It takes the
+// result of a library call directly so song sources can wrap their loaders.
+func (a *App) withMinRating(songs []domain.Song, err error) ([]domain.Song, error) {
+	minRating := a.cfg.UI.MinRating
+	if err != nil || minRating <= 0 {
+		return songs, err
+	}
+	kept := songs[:0]
+	for _, song := range songs {
+		if song.UserRating >= minRating {
+			kept = append(kept, song)
+		}
+	}
+	return kept, nil
+}
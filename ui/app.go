@@ -19,6 +19,8 @@ import (
 	"github.com/yhkl-dev/NaviCLI/domain"
 	"github.com/yhkl-dev/NaviCLI/library"
 	"github.com/yhkl-dev/NaviCLI/player"
+	"github.com/yhkl-dev/NaviCLI/scrobble"
+	"github.com/yhkl-dev/NaviCLI/subsonic"
 )
 
 const dataStartRow = 1
@@ -44,45 +46,94 @@ type App struct {
 	searchInput   *tview.InputField
 	helpView      *HelpView
 	queueView     *QueueView
+	playlistView  *PlaylistView
+	browserView   *BrowserView
+	genreView     *GenreView
+	searchView    *SearchView
+	lyricsView    *LyricsView
+	resumeView    *ResumeView
+	bookmarksView *BookmarksView
+	stationsView  *StationsView
+	podcastView   *PodcastView
+	serverView    *ServerView
+	folderView    *FolderView
+	directoryView *DirectoryView
+	listenersView *ListenersView
 	isSearchMode  bool
 	originalSongs []domain.Song
 	audioMonitor     *device.AudioMonitor
 	tickCount        atomic.Int64
 	serverConnected  atomic.Bool
+	scanning         atomic.Bool  // a library scan started with U is running
+	scanCount        atomic.Int64 // items scanned so far
 	playingUpdateMu  sync.Mutex
 	songsMu          sync.RWMutex
+	songsGen         int // bumped each time totalSongs is replaced by another list
 	cachedTermWidth  int
 	lastWidthCheck   time.Time
 	sortMode         int
-	songSource       int // 0=getRandomSongs, 1=getAlbumList2
+	keepOrder        bool // the list has its own order, such as an album or playlist, and is not sorted
+	songSource       int // index into songSources
 	leftTitleBar     *tview.TextView
 	rightTitleBar    *tview.TextView
-}
-
-var songSources = []struct {
-	name string
-}{
-	{"Random"},
-	{"Albums"},
+	scrobbles        *scrobble.Queue
+	scrobbleMu       sync.Mutex
+	scrobbleSongID   string
+	scrobbleStarted  time.Time
+	scrobbleDone     bool
+	markedSongs      map[string]bool // song IDs marked for playlist actions
+	sourceLabel      string          // overrides the source name for ad-hoc lists such as playlists
+	genre            string          // genre loaded by the By Genre source
+	fromYear         int             // year range loaded by the By Year source
+	toYear           int
+	folderName       string // music folder the library is limited to, "" = all
+	uiState          *config.State
+	sourceView       *SourceView
+	autoExtend       atomic.Bool // append similar songs near the end of the list
+	extending        atomic.Bool
+	recentMu         sync.Mutex
+	recentlyPlayed   []string // song IDs, oldest first, skipped by auto-extend
+	playQueueMu      sync.Mutex
+	bookmarkMu       sync.Mutex
+	bookmarks        map[string]time.Duration // known bookmark positions by song ID
+	streamTitle      atomic.Value    // string: ICY title of the playing radio stream
+	profile          atomic.Value    // string: name of the active transcoding profile
+	streamInfo       atomic.Value    // string: whether the playing track is original or transcoded
+	loadMu           sync.Mutex
+	cancelLoad       context.CancelFunc // cancels the source load in flight
+	loadFailed       atomic.Bool        // the last source load failed because the server was unreachable
+	connectionLost   chan struct{}      // wakes monitorConnection to check more often
 }
 
 var sortModes = []struct {
 	name string
 	less func(a, b domain.Song) bool
 }{
-	{"Random", nil},
+	{"Original", nil},
 	{"Title", func(a, b domain.Song) bool { return a.Title < b.Title }},
 	{"Artist", func(a, b domain.Song) bool { return a.Artist < b.Artist }},
 	{"Album", func(a, b domain.Song) bool {
 		if a.Album != b.Album {
 			return a.Album < b.Album
 		}
+		if a.DiscNumber != b.DiscNumber {
+			return a.DiscNumber < b.DiscNumber
+		}
 		return a.Track < b.Track
 	}},
 }
 
 func NewApp(ctx context.Context, cfg *config.Config, lib library.Library, plr player.Player) *App {
-	return &App{
+	scrobbles, err := scrobble.NewQueue(scrobble.DefaultPath())
+	if err != nil {
+		log.Printf("Failed to load pending scrobbles: %v", err)
+	}
+	uiState, err := config.LoadState()
+	if err != nil {
+		log.Printf("Failed to load saved state: %v", err)
+	}
+
+	app := &App{
 		tviewApp:    tview.NewApplication(),
 		cfg:         cfg,
 		library:     lib,
@@ -93,13 +144,28 @@ func NewApp(ctx context.Context, cfg *config.Config, lib library.Library, plr pl
 		pageSize:    cfg.UI.PageSize,
 		currentPage: 1,
 		sortMode:    1, // default: Title
+		scrobbles:   scrobbles,
+		markedSongs: make(map[string]bool),
+		bookmarks:   make(map[string]time.Duration),
+		uiState:     uiState,
+
+		connectionLost: make(chan struct{}, 1),
 	}
+	app.autoExtend.Store(cfg.UI.AutoExtend)
+	app.restoreSource()
+	app.restoreProfile()
+	lib.OnAvailabilityChange(app.setServerAvailable)
+	return app
 }
 
 func (a *App) Run() error {
 	a.createHomepage()
 	go a.updateProgressBar()
-	go a.loadMusic()
+	go func() {
+		a.restoreMusicFolder()
+		a.loadMusic()
+		a.offerResume()
+	}()
 	go a.monitorConnection()
 	go a.handlePlayerEvents()
 	go a.handleTerminalResize()
@@ -116,30 +182,36 @@ func (a *App) Stop() {
 }
 
 func (a *App) loadMusic() {
-	fetchSize := a.cfg.UI.FetchSize
-	var songs []domain.Song
-	var err error
-
+	ctx := a.startLoad()
 	a.songsMu.RLock()
-	src := a.songSource
+	src := songSources[a.songSource]
 	a.songsMu.RUnlock()
-	if src == 0 {
-		songs, err = a.library.GetRandomSongs(fetchSize)
-	} else {
-		songs, err = a.library.GetAlbumSongs("alphabeticalByName")
-	}
+	songs, err := src.load(ctx, a)
 
+	if ctx.Err() != nil {
+		// superseded by another load, or shutting down
+		return
+	}
 	if err != nil {
+		retry := subsonic.IsUnavailable(err)
+		a.loadFailed.Store(retry)
 		a.tviewApp.QueueUpdateDraw(func() {
 			if a.statusBar != nil {
-				a.statusBar.SetText("[red]Failed to load music: " + err.Error())
+				text := "[red]Failed to load music: " + errorText(err)
+				if retry {
+					text += " [darkgray](retrying once the server is reachable)"
+				}
+				a.statusBar.SetText(text)
 			}
 		})
 		return
 	}
+	a.loadFailed.Store(false)
 
 	a.songsMu.Lock()
 	a.totalSongs = songs
+	a.songsGen++
+	a.keepOrder = false
 	a.totalPages = (len(a.totalSongs) + a.pageSize - 1) / a.pageSize
 	if a.totalPages == 0 {
 		a.totalPages = 1
@@ -152,9 +224,93 @@ func (a *App) loadMusic() {
 	a.tviewApp.QueueUpdateDraw(func() {
 		a.renderSongTable()
 		a.updateStatusWithPageInfo()
+		a.updateSortTitle()
 	})
 }
 
+// startLoad cancels the source load still in flight and returns the context
+// for a new one
+func (a *App) startLoad() context.Context {
+	a.loadMu.Lock()
+	defer a.loadMu.Unlock()
+	if a.cancelLoad != nil {
+		a.cancelLoad()
+	}
+	ctx, cancel := context.WithCancel(a.ctx)
+	a.cancelLoad = cancel
+	return ctx
+}
+
+// stopLoad cancels the source load in flight so it can't replace a list set
+// by other means
+func (a *App) stopLoad() {
+	a.loadMu.Lock()
+	defer a.loadMu.Unlock()
+	if a.cancelLoad != nil {
+		a.cancelLoad()
+		a.cancelLoad = nil
+	}
+}
+
+// setSongs replaces the song list with songs loaded outside the regular
+// sources, such as a playlist or an album. The list keeps its own order, so
+// the sort mode is reset to Original. label is shown in the library title bar.
+func (a *App) setSongs(songs []domain.Song, label string) {
+	a.stopLoad()
+	a.songsMu.Lock()
+	a.totalSongs = songs
+	a.songsGen++
+	a.totalPages = (len(a.totalSongs) + a.pageSize - 1) / a.pageSize
+	if a.totalPages == 0 {
+		a.totalPages = 1
+	}
+	a.currentPage = 1
+	a.keepOrder = true
+	a.sourceLabel = label
+	a.isSearchMode = false
+	a.originalSongs = nil
+	a.songsMu.Unlock()
+
+	a.searchInput.SetText("")
+	a.renderSongTable()
+	a.updateStatusWithPageInfo()
+	a.updateSortTitle()
+	a.songTable.Select(dataStartRow, 0)
+}
+
+// playAlbum loads an album into the song list in disc/track order and starts
+// playing at track index start
+func (a *App) playAlbum(album *domain.Album, start int) {
+	if album == nil || start < 0 || start >= len(album.Songs) {
+		return
+	}
+	a.setSongs(album.Songs, "Album: "+album.Name)
+	go a.playSongAtIndex(start)
+}
+
+// appendSongs adds songs to the end of the song list, which is also the play
+// queue, skipping songs already in it. It returns how many were added.
+func (a *App) appendSongs(songs []domain.Song) int {
+	a.songsMu.Lock()
+	skip := make([]string, len(a.totalSongs))
+	for i, s := range a.totalSongs {
+		skip[i] = s.ID
+	}
+	added := uniqueSongs(songs, skip)
+	a.totalSongs = append(a.totalSongs, added...)
+	a.totalPages = (len(a.totalSongs) + a.pageSize - 1) / a.pageSize
+	if a.totalPages == 0 {
+		a.totalPages = 1
+	}
+	a.songsMu.Unlock()
+
+	if len(added) > 0 {
+		a.renderSongTable()
+		a.updateStatusWithPageInfo()
+	}
+	return len(added)
+}
+
 func (a *App) handlePlayerEvents() {
 	defer func() {
 		if r := recover(); r != nil {
@@ -169,6 +325,9 @@ func (a *App) handlePlayerEvents() {
 			if !ok {
 				return
 			}
+			if event != nil && player.IsMetadataChange(event) {
+				go a.refreshStreamTitle()
+			}
 			if event != nil && event.Event_Id == mpv.EVENT_END_FILE {
 				a.tviewApp.QueueUpdateDraw(func() {
 					a.playNextSong()
@@ -209,26 +368,52 @@ func (a *App) handleTerminalResize() {
 	}
 }
 
+// monitorConnection pings the server to drive the connection indicator. While
+// the server is unreachable, or a load failed, it checks more often so the
+// failed load reruns soon after the server is back.
 func (a *App) monitorConnection() {
-	ticker := time.NewTicker(30 * time.Second)
-	defer ticker.Stop()
+	check := func() {
+		connected := a.library.Ping(a.ctx) == nil
+		a.setServerAvailable(connected)
+		if connected {
+			a.flushScrobbles()
+			a.retryFailedLoad()
+		}
+	}
 
 	// Initial ping
-	a.serverConnected.Store(a.library.Ping() == nil)
+	check()
 
 	for {
+		interval := connectionCheckInterval
+		if !a.serverConnected.Load() || a.loadFailed.Load() {
+			interval = reconnectCheckInterval
+		}
+		timer := time.NewTimer(interval)
 		select {
-		case <-ticker.C:
-			a.serverConnected.Store(a.library.Ping() == nil)
+		case <-timer.C:
+			check()
+		case <-a.connectionLost:
+			timer.Stop()
 		case <-a.ctx.Done():
+			timer.Stop()
 			return
 		}
 	}
 }
 
+// activeSortMode returns the order the song list is shown in: the chosen sort
+// mode, or Original for lists that keep their own order. songsMu must be held.
+func (a *App) activeSortMode() int {
+	if a.keepOrder {
+		return 0
+	}
+	return a.sortMode
+}
+
 func (a *App) SortSongs() {
 	a.songsMu.RLock()
-	mode := sortModes[a.sortMode]
+	mode := sortModes[a.activeSortMode()]
 	a.songsMu.RUnlock()
 	if mode.less == nil {
 		return
@@ -242,7 +427,8 @@ func (a *App) SortSongs() {
 
 func (a *App) cycleSortMode() {
 	a.songsMu.Lock()
-	a.sortMode = (a.sortMode + 1) % len(sortModes)
+	a.sortMode = (a.activeSortMode() + 1) % len(sortModes)
+	a.keepOrder = false
 	a.songsMu.Unlock()
 	a.SortSongs()
 	a.renderSongTable()
@@ -250,21 +436,16 @@ func (a *App) cycleSortMode() {
 	a.updateSortTitle()
 }
 
-func (a *App) cycleSongSource() {
-	a.songsMu.Lock()
-	a.songSource = (a.songSource + 1) % len(songSources)
-	a.songsMu.Unlock()
-	go a.loadMusic()
-	a.updateSortTitle()
-}
-
 func (a *App) updateSortTitle() {
 	a.songsMu.RLock()
-	mode := sortModes[a.sortMode]
-	src := songSources[a.songSource]
+	mode := sortModes[a.activeSortMode()]
+	srcName := a.sourceName()
+	if a.folderName != "" {
+		srcName = a.folderName + " · " + srcName
+	}
 	a.songsMu.RUnlock()
 	if a.rightTitleBar != nil {
-		a.rightTitleBar.SetText(fmt.Sprintf("[#ffb300]── Library  [darkgray][%s · %s]", src.name, mode.name))
+		a.rightTitleBar.SetText(fmt.Sprintf("[#ffb300]── Library  [darkgray][%s · %s]", srcName, mode.name))
 	}
 	if a.leftTitleBar != nil {
 		a.leftTitleBar.SetText(fmt.Sprintf("[#ffb300]── Now Playing  [darkgray][%s]", mode.name))
@@ -305,6 +486,11 @@ func (a *App) getTerminalWidth() int {
 }
 
 func (a *App) playSongAtIndex(index int) {
+	a.playSongFrom(index, 0)
+}
+
+// playSongFrom plays the song at index starting start seconds in
+func (a *App) playSongFrom(index int, start float64) {
 	a.songsMu.RLock()
 	if index < 0 || index >= len(a.totalSongs) {
 		a.songsMu.RUnlock()
@@ -317,12 +503,13 @@ func (a *App) playSongAtIndex(index int) {
 	if loading {
 		return
 	}
+	a.bookmarkIfInterrupted()
 	a.state.SetLoading(true)
 	a.state.SetCurrentSong(&currentTrack, index)
 	a.state.SetPlaying(false)
 
 	loadingStatus := fmt.Sprintf("[#ffb300]%s [darkgray](Loading...)", currentTrack.Title)
-	a.updateStatus(FormatSongInfo(currentTrack, loadingStatus, "", "[darkgray]Vol: [...", a.leftPanelTextWidth(), a.serverConnected.Load(), ""))
+	a.updateStatus(FormatSongInfo(currentTrack, loadingStatus, "", "[darkgray]Vol: [...", a.leftPanelTextWidth(), a.connectionStatus(), ""))
 
 	go func() {
 		defer a.state.SetLoading(false)
@@ -331,16 +518,34 @@ func (a *App) playSongAtIndex(index int) {
 				log.Printf("playSongAtIndex panic: %v", r)
 				a.state.SetPlaying(false)
 				failedStatus := fmt.Sprintf("[red]%s [darkgray](Failed)", currentTrack.Title)
-				a.updateStatus(FormatSongInfo(currentTrack, failedStatus, "", "[darkgray]Vol: [...", a.leftPanelTextWidth(), a.serverConnected.Load(), ""))
+				a.updateStatus(FormatSongInfo(currentTrack, failedStatus, "", "[darkgray]Vol: [...", a.leftPanelTextWidth(), a.connectionStatus(), ""))
 			}
 		}()
 
-		playURL, ok := a.getPlayURL(currentTrack.ID)
+		opts := a.streamOptions()
+		playURL, ok := a.getPlayURL(currentTrack, opts)
 		if !ok {
 			return
 		}
+		a.streamInfo.Store(streamLabel(currentTrack, opts))
 
-		if err := a.player.Play(playURL); err != nil {
+		a.streamTitle.Store("")
+		if start == 0 && currentTrack.IsPodcast {
+			start = a.bookmarkPosition(currentTrack.ID).Seconds()
+		}
+		var err error
+		switch {
+		case start > 0 && a.canStartTranscodeAt(currentTrack, opts):
+			// a transcoded stream can't be seeked, so the server starts it
+			opts.TimeOffset = int(start)
+			playURL, _ = a.getPlayURL(currentTrack, opts)
+			err = a.player.PlayOffset(playURL, float64(opts.TimeOffset))
+		case start > 0:
+			err = a.player.PlayAt(playURL, start)
+		default:
+			err = a.player.Play(playURL)
+		}
+		if err != nil {
 			return
 		}
 
@@ -353,9 +558,17 @@ func (a *App) playSongAtIndex(index int) {
 		})
 
 		a.state.SetPlaying(true)
+		if currentTrack.StreamURL == "" {
+			// radio stations are not library songs: nothing to scrobble, and
+			// radio mode would look up songs similar to an unknown ID
+			a.startScrobble(currentTrack)
+			a.rememberPlayed(currentTrack.ID)
+			a.extendIfNeeded()
+		}
+		a.savePlayQueue()
 
 		playingStatus := fmt.Sprintf("[#ffb300]▶ PLAYING")
-		a.updateStatus(FormatSongInfo(currentTrack, playingStatus, "◴", "[darkgray]Vol: [...", a.leftPanelTextWidth(), a.serverConnected.Load(), CreatePlayingExtras(currentTrack, a.leftPanelTextWidth())))
+		a.updateStatus(FormatSongInfo(currentTrack, playingStatus, "◴", "[darkgray]Vol: [...", a.leftPanelTextWidth(), a.connectionStatus(), CreatePlayingExtras(currentTrack, a.leftPanelTextWidth(), a.playingStreamInfo())))
 
 		a.tviewApp.QueueUpdateDraw(func() {
 			a.renderSongTable()
@@ -363,8 +576,11 @@ func (a *App) playSongAtIndex(index int) {
 	}()
 }
 
-func (a *App) getPlayURL(trackID string) (string, bool) {
-	url := a.library.GetPlayURL(trackID)
+func (a *App) getPlayURL(track domain.Song, opts library.StreamOptions) (string, bool) {
+	if track.StreamURL != "" {
+		return track.StreamURL, true
+	}
+	url := a.library.GetPlayURL(track.ID, opts)
 	return url, url != ""
 }
 
@@ -460,6 +676,23 @@ func (a *App) getCurrentPageData() []domain.Song {
 	return a.totalSongs[start:end]
 }
 
+// selectedSongIndex returns the index into totalSongs of the selected row,
+// or -1 if no song is selected. Radio streams count as no song, since stars,
+// ratings and playlists only apply to library songs.
+func (a *App) selectedSongIndex() int {
+	row, _ := a.songTable.GetSelection()
+	if row < dataStartRow {
+		return -1
+	}
+	index := (a.currentPage-1)*a.pageSize + (row - dataStartRow)
+	a.songsMu.RLock()
+	defer a.songsMu.RUnlock()
+	if index >= len(a.totalSongs) || a.totalSongs[index].StreamURL != "" {
+		return -1
+	}
+	return index
+}
+
 // nextPage moves to the next page
 func (a *App) nextPage() {
 	if a.currentPage < a.totalPages {
@@ -485,6 +718,9 @@ func (a *App) updateStatusWithPageInfo() {
 	a.songsMu.RUnlock()
 	pageInfo := fmt.Sprintf("[gray]Page %d/%d | %d songs total",
 		a.currentPage, a.totalPages, songCount)
+	if a.scanning.Load() {
+		pageInfo += fmt.Sprintf("\n[#ffb300]Scanning library: %d items", a.scanCount.Load())
+	}
 
 	currentSong, _, isPlaying, _ := a.state.GetState()
 	if currentSong != nil && isPlaying {
@@ -524,6 +760,14 @@ func (a *App) moveRowUp() {
 	}
 }
 
+// goToSongPage shows the page holding the song at index and selects it
+func (a *App) goToSongPage(index int) {
+	a.currentPage = index/a.pageSize + 1
+	a.renderSongTable()
+	a.updateStatusWithPageInfo()
+	a.songTable.Select(dataStartRow+index%a.pageSize, 0)
+}
+
 func (a *App) goToFirstPage() {
 	if a.currentPage != 1 {
 		a.currentPage = 1
@@ -582,7 +826,7 @@ func (a *App) onAudioDeviceDisconnected() {
 	}
 
 	pausedStatus := fmt.Sprintf("[#ff9800]⏸ PAUSED")
-	statusText := FormatSongInfo(*currentSong, pausedStatus, "◷", "[darkgray]Vol: [...", a.leftPanelTextWidth(), a.serverConnected.Load(), "")
+	statusText := FormatSongInfo(*currentSong, pausedStatus, "◷", "[darkgray]Vol: [...", a.leftPanelTextWidth(), a.connectionStatus(), "")
 
 	a.tviewApp.QueueUpdateDraw(func() {
 		if a.statusBar != nil {
@@ -0,0 +1,321 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/yhkl-dev/NaviCLI/domain"
+)
+
+const (
+	bookmarkMargin  = 30 * time.Second // stops this close to either end aren't bookmarked
+	bookmarkComment = "Saved by NaviCLI"
+)
+
+// interruptedBookmark returns the bookmark to save for the playing song, or
+// nil when it is too short or stopped near its start or end. Podcast episodes
+// are bookmarked whatever their length. It must run before the player moves
+// on to another song.
+func (a *App) interruptedBookmark() *domain.Bookmark {
+	minLength := time.Duration(a.cfg.Player.BookmarkMinLength) * time.Second
+	song, _, _, _ := a.state.GetState()
+	if song == nil || (minLength <= 0 && !song.IsPodcast) {
+		return nil
+	}
+	if song.IsPodcast {
+		minLength = 0
+	}
+
+	pos, total, err := a.player.GetProgress()
+	if err != nil || total <= 0 {
+		return nil // nothing loaded: the song ended on its own
+	}
+	position := time.Duration(pos * float64(time.Second))
+	duration := time.Duration(total * float64(time.Second))
+	if duration < minLength || position < bookmarkMargin || position > duration-bookmarkMargin {
+		return nil
+	}
+	return &domain.Bookmark{Song: *song, Position: position, Comment: bookmarkComment}
+}
+
+// saveBookmark creates a bookmark and remembers it so it can be removed once
+// the song is played to the end
+func (a *App) saveBookmark(ctx context.Context, b *domain.Bookmark) error {
+	if err := a.library.CreateBookmark(ctx, b.Song.ID, b.Position, b.Comment); err != nil {
+		return err
+	}
+	a.bookmarkMu.Lock()
+	a.bookmarks[b.Song.ID] = b.Position
+	a.bookmarkMu.Unlock()
+	return nil
+}
+
+// bookmarkPosition returns the saved position of a song, or 0 if it has no
+// known bookmark
+func (a *App) bookmarkPosition(id string) time.Duration {
+	a.bookmarkMu.Lock()
+	defer a.bookmarkMu.Unlock()
+	return a.bookmarks[id]
+}
+
+// rememberBookmarks records bookmarks loaded from the server
+func (a *App) rememberBookmarks(bookmarks []domain.Bookmark) {
+	a.bookmarkMu.Lock()
+	defer a.bookmarkMu.Unlock()
+	for _, b := range bookmarks {
+		a.bookmarks[b.Song.ID] = b.Position
+	}
+}
+
+// bookmarkIfInterrupted bookmarks the playing song in the background when it
+// is long and being stopped mid-way
+func (a *App) bookmarkIfInterrupted() {
+	b := a.interruptedBookmark()
+	if b == nil {
+		return
+	}
+	go func() {
+		if err := a.saveBookmark(a.ctx, b); err != nil {
+			log.Printf("Failed to bookmark %s: %v", b.Song.Title, err)
+		}
+	}()
+}
+
+// checkBookmark drops the bookmark of a song that has been played to its
+// end. It is called from the progress ticker.
+func (a *App) checkBookmark(song *domain.Song, currentPos, totalDuration float64) {
+	if time.Duration((totalDuration-currentPos)*float64(time.Second)) > bookmarkMargin {
+		return
+	}
+	a.bookmarkMu.Lock()
+	if _, ok := a.bookmarks[song.ID]; !ok {
+		a.bookmarkMu.Unlock()
+		return
+	}
+	delete(a.bookmarks, song.ID)
+	a.bookmarkMu.Unlock()
+
+	go func() {
+		if err := a.library.DeleteBookmark(a.ctx, song.ID); err != nil {
+			log.Printf("Failed to delete bookmark for %s: %v", song.Title, err)
+		}
+	}()
+}
+
+// BookmarksView lists saved positions and resumes playback from them
+type BookmarksView struct {
+	app       *App
+	container *tview.Flex
+	table     *tview.Table
+	footer    *tview.TextView
+	isActive  bool
+	bookmarks []domain.Bookmark
+}
+
+func NewBookmarksView(app *App) *BookmarksView {
+	bv := &BookmarksView{
+		app: app,
+	}
+
+	bv.table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	bv.table.SetSelectedStyle(tcell.StyleDefault.
+		Background(tcell.NewHexColor(0xffb300)).
+		Foreground(tcell.ColorWhite))
+	bv.table.SetInputCapture(bv.handleKey)
+
+	bv.footer = tview.NewTextView().
+		SetDynamicColors(true)
+
+	bv.container = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(bv.table, 0, 1, true).
+		AddItem(bv.footer, 1, 0, false)
+
+	bv.container.SetBorder(true).
+		SetTitle(" Bookmarks (ESC to close) ").
+		SetBorderColor(tcell.NewHexColor(0xffb300)).
+		SetTitleColor(tcell.NewHexColor(0xffb300))
+
+	return bv
+}
+
+// Show displays the bookmarks view
+func (bv *BookmarksView) Show() {
+	bv.isActive = true
+	bv.app.tviewApp.SetFocus(bv.table)
+	bv.render()
+	bv.load()
+}
+
+// Close hides the bookmarks view
+func (bv *BookmarksView) Close() {
+	bv.isActive = false
+	bv.app.tviewApp.SetRoot(bv.app.rootFlex, true)
+	bv.app.tviewApp.SetFocus(bv.app.songTable)
+}
+
+// IsActive returns whether the bookmarks view is active
+func (bv *BookmarksView) IsActive() bool {
+	return bv.isActive
+}
+
+// GetContainer returns the bookmarks view container
+func (bv *BookmarksView) GetContainer() *tview.Flex {
+	return bv.container
+}
+
+func (bv *BookmarksView) setFooter(text string) {
+	bv.footer.SetText("  " + text)
+}
+
+func (bv *BookmarksView) load() {
+	bv.setFooter("[darkgray]Loading bookmarks...")
+	go func() {
+		bookmarks, err := bv.app.library.GetBookmarks(bv.app.ctx)
+		if err == nil {
+			bv.app.rememberBookmarks(bookmarks)
+		}
+		bv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				bv.setFooter("[red]Loading bookmarks failed: " + errorText(err))
+				return
+			}
+			bv.bookmarks = bookmarks
+			bv.render()
+			bv.table.Select(1, 0)
+		})
+	}()
+}
+
+func (bv *BookmarksView) render() {
+	for i := bv.table.GetRowCount() - 1; i >= 0; i-- {
+		bv.table.RemoveRow(i)
+	}
+
+	headerStyle := tcell.StyleDefault.Foreground(tcell.NewHexColor(0xffb300)).Attributes(tcell.AttrBold)
+	for col, title := range []string{"Title", "Artist", "Position", "Saved"} {
+		cell := tview.NewTableCell(title).SetStyle(headerStyle)
+		if col < 2 {
+			cell.SetExpansion(1)
+		}
+		bv.table.SetCell(0, col, cell)
+	}
+
+	rowStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	for i, b := range bv.bookmarks {
+		row := i + 1
+		position := fmt.Sprintf("%s / %s", FormatDuration(int(b.Position.Seconds())), FormatDuration(b.Song.Duration))
+		bv.table.SetCell(row, 0, tview.NewTableCell(b.Song.Title).SetStyle(rowStyle).SetExpansion(1))
+		bv.table.SetCell(row, 1, tview.NewTableCell(b.Song.Artist).SetStyle(rowStyle.Foreground(tcell.ColorGray)).SetExpansion(1))
+		bv.table.SetCell(row, 2, tview.NewTableCell(position).
+			SetStyle(rowStyle.Foreground(tcell.NewHexColor(0xffb300))).SetAlign(tview.AlignRight))
+		bv.table.SetCell(row, 3, tview.NewTableCell(b.Changed.Local().Format("2006-01-02 15:04")).
+			SetStyle(rowStyle.Foreground(tcell.ColorGray)))
+	}
+
+	if len(bv.bookmarks) == 0 {
+		bv.setFooter("[darkgray]No bookmarks  [darkgray]a [white]bookmark playing song  [darkgray]ESC [white]close")
+		return
+	}
+	bv.setFooter("[darkgray]ENTER [white]resume  [darkgray]a [white]bookmark playing song  [darkgray]d [white]delete  [darkgray]ESC [white]close")
+}
+
+func (bv *BookmarksView) selected() (int, *domain.Bookmark) {
+	row, _ := bv.table.GetSelection()
+	if row <= 0 || row > len(bv.bookmarks) {
+		return row, nil
+	}
+	return row, &bv.bookmarks[row-1]
+}
+
+func (bv *BookmarksView) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	if event.Key() == tcell.KeyEnter {
+		bv.resume()
+		return nil
+	}
+
+	switch event.Rune() {
+	case 'a':
+		bv.addPlaying()
+	case 'd':
+		bv.deleteSelected()
+	default:
+		return event
+	}
+	return nil
+}
+
+// resume loads every bookmarked song into the song list and plays the
+// selected one from its saved position
+func (bv *BookmarksView) resume() {
+	row, b := bv.selected()
+	if b == nil {
+		return
+	}
+	songs := make([]domain.Song, len(bv.bookmarks))
+	for i := range bv.bookmarks {
+		songs[i] = bv.bookmarks[i].Song
+	}
+	position := b.Position.Seconds()
+
+	bv.Close()
+	bv.app.setSongs(songs, "Bookmarks")
+	bv.app.goToSongPage(row - 1)
+	go bv.app.playSongFrom(row-1, position)
+}
+
+// addPlaying bookmarks the playing song at its current position
+func (bv *BookmarksView) addPlaying() {
+	song, _, _, _ := bv.app.state.GetState()
+	if song == nil {
+		bv.setFooter("[darkgray]Nothing is playing")
+		return
+	}
+	pos, _, err := bv.app.player.GetProgress()
+	if err != nil {
+		bv.setFooter("[red]Reading the position failed: " + errorText(err))
+		return
+	}
+
+	b := &domain.Bookmark{Song: *song, Position: time.Duration(pos * float64(time.Second))}
+	go func() {
+		err := bv.app.saveBookmark(bv.app.ctx, b)
+		bv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				bv.setFooter("[red]Saving bookmark failed: " + errorText(err))
+				return
+			}
+			bv.load()
+		})
+	}()
+}
+
+func (bv *BookmarksView) deleteSelected() {
+	_, b := bv.selected()
+	if b == nil {
+		return
+	}
+	id := b.Song.ID
+	go func() {
+		err := bv.app.library.DeleteBookmark(bv.app.ctx, id)
+		if err == nil {
+			bv.app.bookmarkMu.Lock()
+			delete(bv.app.bookmarks, id)
+			bv.app.bookmarkMu.Unlock()
+		}
+		bv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				bv.setFooter("[red]Deleting bookmark failed: " + errorText(err))
+				return
+			}
+			bv.load()
+		})
+	}()
+}
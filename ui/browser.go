@@ -0,0 +1,438 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/yhkl-dev/NaviCLI/domain"
+)
+
+type browserLevel int
+
+const (
+	browseArtists browserLevel = iota
+	browseAlbums
+	browseTracks
+)
+
+// BrowserView drills down from artists to their albums and tracks
+type BrowserView struct {
+	app       *App
+	container *tview.Flex
+	table     *tview.Table
+	footer    *tview.TextView
+	isActive  bool
+
+	level      browserLevel
+	indexes    []domain.ArtistIndex
+	artistRows []*domain.Artist // table row -> artist, nil for index letter rows
+	artist     *domain.Artist
+	album      *domain.Album
+	artistRow  int // selection to restore when going back up
+	albumRow   int
+}
+
+func NewBrowserView(app *App) *BrowserView {
+	bv := &BrowserView{
+		app: app,
+	}
+
+	bv.table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	bv.table.SetSelectedStyle(tcell.StyleDefault.
+		Background(tcell.NewHexColor(0xffb300)).
+		Foreground(tcell.ColorWhite))
+	bv.table.SetInputCapture(bv.handleKey)
+
+	bv.footer = tview.NewTextView().
+		SetDynamicColors(true)
+
+	bv.container = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(bv.table, 0, 1, true).
+		AddItem(bv.footer, 1, 0, false)
+
+	bv.container.SetBorder(true).
+		SetBorderColor(tcell.NewHexColor(0xffb300)).
+		SetTitleColor(tcell.NewHexColor(0xffb300))
+
+	return bv
+}
+
+// Show displays the browser at the artist level
+func (bv *BrowserView) Show() {
+	bv.isActive = true
+	bv.level = browseArtists
+	bv.artist = nil
+	bv.album = nil
+	bv.app.tviewApp.SetFocus(bv.table)
+	bv.render()
+	if bv.indexes == nil {
+		bv.loadArtists()
+	}
+}
+
+// ShowArtist displays the browser at one artist's albums
+func (bv *BrowserView) ShowArtist(id string) {
+	bv.isActive = true
+	bv.app.tviewApp.SetFocus(bv.table)
+	bv.table.Clear()
+	bv.artistRow = 0
+	bv.loadArtist(id)
+}
+
+// ShowAlbum displays the browser at one album's tracks
+func (bv *BrowserView) ShowAlbum(id string) {
+	bv.isActive = true
+	bv.app.tviewApp.SetFocus(bv.table)
+	bv.table.Clear()
+	bv.artist = nil
+	bv.albumRow = 0
+	bv.loadAlbum(id, func(album *domain.Album) {
+		bv.level = browseTracks
+		bv.album = album
+		bv.render()
+		bv.table.Select(1, 0)
+	})
+}
+
+// Close hides the browser view
+func (bv *BrowserView) Close() {
+	bv.isActive = false
+	bv.app.tviewApp.SetRoot(bv.app.rootFlex, true)
+	bv.app.tviewApp.SetFocus(bv.app.songTable)
+}
+
+// IsActive returns whether the browser view is active
+func (bv *BrowserView) IsActive() bool {
+	return bv.isActive
+}
+
+// GetContainer returns the browser view container
+func (bv *BrowserView) GetContainer() *tview.Flex {
+	return bv.container
+}
+
+func (bv *BrowserView) setFooter(text string) {
+	bv.footer.SetText("  " + text)
+}
+
+func (bv *BrowserView) loadArtists() {
+	bv.setFooter("[darkgray]Loading artists...")
+	go func() {
+		indexes, err := bv.app.library.GetArtistIndexes(bv.app.ctx)
+		bv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				bv.setFooter("[red]Loading artists failed: " + errorText(err))
+				return
+			}
+			bv.indexes = indexes
+			if bv.level == browseArtists {
+				bv.render()
+				bv.table.Select(1, 0)
+			}
+		})
+	}()
+}
+
+func (bv *BrowserView) render() {
+	for i := bv.table.GetRowCount() - 1; i >= 0; i-- {
+		bv.table.RemoveRow(i)
+	}
+
+	switch bv.level {
+	case browseArtists:
+		bv.container.SetTitle(" Artists (ESC to close) ")
+		bv.setFooter("[darkgray]ENTER [white]albums  [darkgray]f [white]star  [darkgray]ESC [white]close")
+		bv.renderArtists()
+	case browseAlbums:
+		bv.container.SetTitle(fmt.Sprintf(" %s (ESC to go back) ", bv.artist.Name))
+		bv.setFooter("[darkgray]ENTER [white]tracks  [darkgray]p [white]play album  [darkgray]f [white]star  [darkgray]ESC [white]back")
+		bv.renderAlbums()
+	case browseTracks:
+		bv.container.SetTitle(fmt.Sprintf(" %s · %s (ESC to go back) ", bv.album.Artist, bv.album.Name))
+		bv.setFooter("[darkgray]ENTER [white]play from here  [darkgray]p [white]play album  [darkgray]ESC [white]back")
+		bv.renderTracks()
+	}
+}
+
+func (bv *BrowserView) setHeaders(titles ...string) {
+	headerStyle := tcell.StyleDefault.Foreground(tcell.NewHexColor(0xffb300)).Attributes(tcell.AttrBold)
+	for col, title := range titles {
+		cell := tview.NewTableCell(title).SetStyle(headerStyle)
+		if col == 1 {
+			cell.SetExpansion(1)
+		}
+		bv.table.SetCell(0, col, cell)
+	}
+}
+
+func starMark(starred *time.Time) string {
+	if starred != nil {
+		return "★ "
+	}
+	return ""
+}
+
+func (bv *BrowserView) renderArtists() {
+	bv.setHeaders("", "Artist", "Albums")
+	bv.artistRows = []*domain.Artist{nil}
+
+	rowStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	row := 1
+	for i := range bv.indexes {
+		index := &bv.indexes[i]
+		bv.table.SetCell(row, 0, tview.NewTableCell(index.Name).
+			SetStyle(rowStyle.Foreground(tcell.NewHexColor(0xffb300)).Attributes(tcell.AttrBold)).
+			SetSelectable(false))
+		bv.table.SetCell(row, 1, tview.NewTableCell("").SetSelectable(false))
+		bv.table.SetCell(row, 2, tview.NewTableCell("").SetSelectable(false))
+		bv.artistRows = append(bv.artistRows, nil)
+		row++
+
+		for j := range index.Artists {
+			artist := &index.Artists[j]
+			bv.table.SetCell(row, 0, tview.NewTableCell(""))
+			bv.table.SetCell(row, 1, tview.NewTableCell(starMark(artist.Starred)+artist.Name).SetStyle(rowStyle))
+			bv.table.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%d", artist.AlbumCount)).
+				SetStyle(rowStyle.Foreground(tcell.ColorGray)).SetAlign(tview.AlignRight))
+			bv.artistRows = append(bv.artistRows, artist)
+			row++
+		}
+	}
+}
+
+func (bv *BrowserView) renderAlbums() {
+	bv.setHeaders("Year", "Album", "Songs", "Duration")
+	rowStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	for i, album := range bv.artist.Albums {
+		row := i + 1
+		year := ""
+		if album.Year > 0 {
+			year = fmt.Sprintf("%d", album.Year)
+		}
+		bv.table.SetCell(row, 0, tview.NewTableCell(year).SetStyle(rowStyle.Foreground(tcell.NewHexColor(0xffb300))))
+		bv.table.SetCell(row, 1, tview.NewTableCell(starMark(album.Starred)+album.Name).SetStyle(rowStyle))
+		bv.table.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%d", album.SongCount)).
+			SetStyle(rowStyle.Foreground(tcell.ColorGray)).SetAlign(tview.AlignRight))
+		bv.table.SetCell(row, 3, tview.NewTableCell(FormatDuration(album.Duration)).
+			SetStyle(rowStyle.Foreground(tcell.ColorGray)).SetAlign(tview.AlignRight))
+	}
+}
+
+func (bv *BrowserView) renderTracks() {
+	bv.setHeaders("#", "Title", "Duration")
+	rowStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	multiDisc := len(bv.album.Songs) > 0 && bv.album.Songs[len(bv.album.Songs)-1].DiscNumber > 1
+	for i, song := range bv.album.Songs {
+		row := i + 1
+		number := fmt.Sprintf("%d", song.Track)
+		if multiDisc {
+			number = fmt.Sprintf("%d-%02d", song.DiscNumber, song.Track)
+		}
+		bv.table.SetCell(row, 0, tview.NewTableCell(number).
+			SetStyle(rowStyle.Foreground(tcell.NewHexColor(0xffb300))).SetAlign(tview.AlignRight))
+		bv.table.SetCell(row, 1, tview.NewTableCell(starMark(song.Starred)+song.Title).SetStyle(rowStyle))
+		bv.table.SetCell(row, 2, tview.NewTableCell(FormatDuration(song.Duration)).
+			SetStyle(rowStyle.Foreground(tcell.ColorGray)).SetAlign(tview.AlignRight))
+	}
+}
+
+func (bv *BrowserView) selectedArtist() *domain.Artist {
+	row, _ := bv.table.GetSelection()
+	if row <= 0 || row >= len(bv.artistRows) {
+		return nil
+	}
+	return bv.artistRows[row]
+}
+
+func (bv *BrowserView) selectedAlbum() *domain.Album {
+	row, _ := bv.table.GetSelection()
+	if bv.artist == nil || row <= 0 || row > len(bv.artist.Albums) {
+		return nil
+	}
+	return &bv.artist.Albums[row-1]
+}
+
+func (bv *BrowserView) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyEscape, tcell.KeyBackspace, tcell.KeyBackspace2, tcell.KeyLeft:
+		bv.goUp(event.Key() == tcell.KeyEscape)
+		return nil
+	case tcell.KeyEnter:
+		bv.open()
+		return nil
+	}
+
+	switch event.Rune() {
+	case 'p':
+		bv.playSelected()
+	case 'f':
+		bv.toggleStar()
+	default:
+		return event
+	}
+	return nil
+}
+
+// goUp returns to the previous level. At the artist level only ESC closes
+// the view so a stray backspace doesn't dismiss it.
+func (bv *BrowserView) goUp(closeAtTop bool) {
+	// ShowArtist and ShowAlbum enter below the top level, so the level
+	// above may still need loading
+	switch bv.level {
+	case browseTracks:
+		if bv.artist == nil && bv.album.ArtistID != "" {
+			bv.albumRow = 0
+			bv.loadArtist(bv.album.ArtistID)
+			return
+		}
+		if bv.artist != nil {
+			bv.level = browseAlbums
+			bv.album = nil
+			bv.render()
+			bv.table.Select(bv.albumRow, 0)
+			return
+		}
+		// an album without an artist ID, such as a compilation, has no
+		// artist page to go back to
+		bv.album = nil
+		fallthrough
+	case browseAlbums:
+		bv.level = browseArtists
+		bv.artist = nil
+		bv.render()
+		bv.table.Select(bv.artistRow, 0)
+		if bv.indexes == nil {
+			bv.loadArtists()
+		}
+	default:
+		if closeAtTop {
+			bv.Close()
+		}
+	}
+}
+
+func (bv *BrowserView) open() {
+	row, _ := bv.table.GetSelection()
+	switch bv.level {
+	case browseArtists:
+		if artist := bv.selectedArtist(); artist != nil {
+			bv.artistRow = row
+			bv.loadArtist(artist.ID)
+		}
+	case browseAlbums:
+		if album := bv.selectedAlbum(); album != nil {
+			bv.albumRow = row
+			bv.loadAlbum(album.ID, func(album *domain.Album) {
+				bv.level = browseTracks
+				bv.album = album
+				bv.render()
+				bv.table.Select(1, 0)
+			})
+		}
+	case browseTracks:
+		if row > 0 {
+			bv.app.playAlbum(bv.album, row-1)
+			bv.Close()
+		}
+	}
+}
+
+func (bv *BrowserView) playSelected() {
+	switch bv.level {
+	case browseAlbums:
+		if album := bv.selectedAlbum(); album != nil {
+			bv.loadAlbum(album.ID, func(album *domain.Album) {
+				bv.app.playAlbum(album, 0)
+				bv.Close()
+			})
+		}
+	case browseTracks:
+		bv.app.playAlbum(bv.album, 0)
+		bv.Close()
+	}
+}
+
+func (bv *BrowserView) loadArtist(id string) {
+	bv.setFooter("[darkgray]Loading albums...")
+	go func() {
+		artist, err := bv.app.library.GetArtist(bv.app.ctx, id)
+		bv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				bv.setFooter("[red]Loading albums failed: " + errorText(err))
+				return
+			}
+			bv.level = browseAlbums
+			bv.artist = artist
+			bv.render()
+			bv.table.Select(1, 0)
+		})
+	}()
+}
+
+func (bv *BrowserView) loadAlbum(id string, done func(album *domain.Album)) {
+	bv.setFooter("[darkgray]Loading tracks...")
+	go func() {
+		album, err := bv.app.library.GetAlbum(bv.app.ctx, id)
+		bv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				bv.setFooter("[red]Loading tracks failed: " + errorText(err))
+				return
+			}
+			done(album)
+		})
+	}()
+}
+
+// toggleStar stars or unstars the selected artist, album or track
+func (bv *BrowserView) toggleStar() {
+	var kind domain.ItemKind
+	var id string
+	var starred **time.Time
+
+	row, _ := bv.table.GetSelection()
+	switch bv.level {
+	case browseArtists:
+		artist := bv.selectedArtist()
+		if artist == nil {
+			return
+		}
+		kind, id, starred = domain.ItemArtist, artist.ID, &artist.Starred
+	case browseAlbums:
+		album := bv.selectedAlbum()
+		if album == nil {
+			return
+		}
+		kind, id, starred = domain.ItemAlbum, album.ID, &album.Starred
+	case browseTracks:
+		if row <= 0 || row > len(bv.album.Songs) {
+			return
+		}
+		song := &bv.album.Songs[row-1]
+		kind, id, starred = domain.ItemSong, song.ID, &song.Starred
+	}
+
+	star := *starred == nil
+	go func() {
+		err := bv.app.library.SetStarred(bv.app.ctx, kind, id, star)
+		bv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				bv.setFooter("[red]Failed to update star: " + errorText(err))
+				return
+			}
+			if star {
+				now := time.Now()
+				*starred = &now
+			} else {
+				*starred = nil
+			}
+			bv.render()
+			bv.table.Select(row, 0)
+		})
+	}()
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -37,8 +38,22 @@ func (a *App) createHomepage() {
 
 	a.helpView = NewHelpView(a)
 	a.queueView = NewQueueView(a)
-
-	a.setupTableHeaders()
+	a.playlistView = NewPlaylistView(a)
+	a.browserView = NewBrowserView(a)
+	a.genreView = NewGenreView(a)
+	a.searchView = NewSearchView(a)
+	a.lyricsView = NewLyricsView(a)
+	a.resumeView = NewResumeView(a)
+	a.bookmarksView = NewBookmarksView(a)
+	a.stationsView = NewStationsView(a)
+	a.podcastView = NewPodcastView(a)
+	a.serverView = NewServerView(a)
+	a.folderView = NewFolderView(a)
+	a.directoryView = NewDirectoryView(a)
+	a.listenersView = NewListenersView(a)
+	a.sourceView = NewSourceView(a)
+
+	a.setupTableHeaders(a.getTerminalWidth())
 	a.setupSearchInput()
 	a.setupInputHandlers()
 
@@ -74,7 +89,7 @@ func (a *App) createHomepage() {
 	a.tviewApp.SetRoot(a.rootFlex, true)
 }
 
-func (a *App) setupTableHeaders() {
+func (a *App) setupTableHeaders(termWidth int) {
 	headerStyle := tcell.StyleDefault.Foreground(tcell.NewHexColor(0xffb300)).Attributes(tcell.AttrBold)
 
 	a.songTable.SetCell(0, 0, tview.NewTableCell("#").SetStyle(headerStyle).SetAlign(tview.AlignRight))
@@ -82,6 +97,12 @@ func (a *App) setupTableHeaders() {
 	a.songTable.SetCell(0, 2, tview.NewTableCell("Duration").SetStyle(headerStyle).SetAlign(tview.AlignRight))
 	a.songTable.SetCell(0, 3, tview.NewTableCell("Artist").SetStyle(headerStyle))
 	a.songTable.SetCell(0, 4, tview.NewTableCell("Album").SetStyle(headerStyle))
+	// renderSongTable only fills the rating column from 80 columns on
+	if a.cfg.UI.ShowRating && termWidth >= 80 {
+		a.songTable.SetCell(0, 5, tview.NewTableCell("Rating").SetStyle(headerStyle))
+	} else if a.songTable.GetColumnCount() > 5 {
+		a.songTable.RemoveColumn(5)
+	}
 }
 
 func (a *App) setupSearchInput() {
@@ -231,10 +252,132 @@ func (a *App) setupKeyBindings() {
 	)
 
 	km.RegisterKeyBinding(
-		KeyAction{name: "source", handler: a.cycleSongSource},
+		KeyAction{name: "source", handler: a.showSources},
 		[]tcell.Key{},
 		[]rune{'S'},
 	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "star", handler: a.toggleStar},
+		[]tcell.Key{},
+		[]rune{'f'},
+	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "mark", handler: a.toggleMark},
+		[]tcell.Key{},
+		[]rune{'m'},
+	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "addToPlaylist", handler: a.addToPlaylist},
+		[]tcell.Key{},
+		[]rune{'a'},
+	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "playlists", handler: a.showPlaylists},
+		[]tcell.Key{},
+		[]rune{'L'},
+	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "browse", handler: a.showBrowser},
+		[]tcell.Key{},
+		[]rune{'b'},
+	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "genres", handler: a.showGenres},
+		[]tcell.Key{},
+		[]rune{'e'},
+	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "lyrics", handler: a.showLyrics},
+		[]tcell.Key{},
+		[]rune{'y'},
+	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "trackRadio", handler: a.startTrackRadio},
+		[]tcell.Key{},
+		[]rune{'r'},
+	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "artistRadio", handler: a.startArtistRadio},
+		[]tcell.Key{},
+		[]rune{'R'},
+	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "autoExtend", handler: a.toggleAutoExtend},
+		[]tcell.Key{},
+		[]rune{'E'},
+	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "bookmarks", handler: a.showBookmarks},
+		[]tcell.Key{},
+		[]rune{'B'},
+	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "stations", handler: a.showStations},
+		[]tcell.Key{},
+		[]rune{'I'},
+	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "podcasts", handler: a.showPodcasts},
+		[]tcell.Key{},
+		[]rune{'c'},
+	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "transcodeProfile", handler: a.cycleProfile},
+		[]tcell.Key{},
+		[]rune{'t'},
+	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "serverInfo", handler: a.showServerInfo},
+		[]tcell.Key{},
+		[]rune{'i'},
+	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "musicFolders", handler: a.showFolders},
+		[]tcell.Key{},
+		[]rune{'F'},
+	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "directories", handler: a.showDirectories},
+		[]tcell.Key{},
+		[]rune{'d'},
+	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "scanLibrary", handler: a.startScan},
+		[]tcell.Key{},
+		[]rune{'U'},
+	)
+
+	km.RegisterKeyBinding(
+		KeyAction{name: "listeners", handler: a.showListeners},
+		[]tcell.Key{},
+		[]rune{'w'},
+	)
+
+	for rating := 0; rating <= 5; rating++ {
+		km.RegisterKeyBinding(
+			KeyAction{name: "rate", handler: func() { a.rateSelected(rating) }},
+			[]tcell.Key{},
+			[]rune{rune('0' + rating)},
+		)
+	}
 }
 
 func (a *App) setupGlobalInputHandler() {
@@ -253,6 +396,90 @@ func (a *App) setupGlobalInputHandler() {
 			}
 			return event
 		}
+		if a.playlistView != nil && a.playlistView.IsActive() {
+			// The playlist view handles ESC itself: it steps back from edit mode
+			return event
+		}
+		if a.browserView != nil && a.browserView.IsActive() {
+			return event
+		}
+		if a.sourceView != nil && a.sourceView.IsActive() {
+			if event.Key() == tcell.KeyEscape && a.tviewApp.GetFocus() != a.sourceView.input {
+				a.sourceView.Close()
+				return nil
+			}
+			return event
+		}
+		if a.searchView != nil && a.searchView.IsActive() {
+			if event.Key() == tcell.KeyEscape {
+				a.searchView.Close()
+				return nil
+			}
+			return event
+		}
+		if a.resumeView != nil && a.resumeView.IsActive() {
+			return event
+		}
+		if a.stationsView != nil && a.stationsView.IsActive() {
+			// ESC in the station list closes it; in the name/URL prompt it cancels
+			return event
+		}
+		if a.podcastView != nil && a.podcastView.IsActive() {
+			// ESC in the episode list goes back to the channels
+			return event
+		}
+		if a.bookmarksView != nil && a.bookmarksView.IsActive() {
+			if event.Key() == tcell.KeyEscape {
+				a.bookmarksView.Close()
+				return nil
+			}
+			return event
+		}
+		if a.listenersView != nil && a.listenersView.IsActive() {
+			if event.Key() == tcell.KeyEscape || event.Rune() == 'w' {
+				a.listenersView.Close()
+				return nil
+			}
+			return event
+		}
+		if a.directoryView != nil && a.directoryView.IsActive() {
+			if event.Key() == tcell.KeyEscape {
+				a.directoryView.Close()
+				return nil
+			}
+			return event
+		}
+		if a.folderView != nil && a.folderView.IsActive() {
+			if event.Key() == tcell.KeyEscape {
+				a.folderView.Close()
+				return nil
+			}
+			return event
+		}
+		if a.serverView != nil && a.serverView.IsActive() {
+			if event.Key() == tcell.KeyEscape || event.Rune() == 'i' {
+				a.serverView.Close()
+				return nil
+			}
+			return event
+		}
+		if a.lyricsView != nil && a.lyricsView.IsActive() {
+			if event.Key() == tcell.KeyEscape || event.Rune() == 'y' {
+				a.lyricsView.Close()
+				return nil
+			}
+			return event
+		}
+		if a.genreView != nil && a.genreView.IsActive() {
+			if event.Key() == tcell.KeyEscape {
+				a.genreView.Close()
+				return nil
+			}
+			return event
+		}
+		if _, ok := a.tviewApp.GetFocus().(*tview.InputField); ok {
+			return event
+		}
 
 		if a.keyBindings.HandleKey(event) {
 			return nil
@@ -305,11 +532,39 @@ func (a *App) handleSpaceKey() {
 			a.updatePlayingDisplay(currentSong)
 		} else {
 			a.updatePausedDisplay(currentSong)
+			a.savePlayQueue()
 		}
 	}()
 }
 
+// exitSaveWindow bounds how long quitting waits for the server
+const exitSaveWindow = 2 * time.Second
+
+// syncOnExit saves the play queue and bookmarks an interrupted long track
+// before the player shuts down, giving up after exitSaveWindow
+func (a *App) syncOnExit() {
+	ctx, cancel := context.WithTimeout(a.ctx, exitSaveWindow)
+	defer cancel()
+
+	bookmark := a.interruptedBookmark()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		a.syncPlayQueue(ctx)
+		if bookmark != nil {
+			if err := a.saveBookmark(ctx, bookmark); err != nil {
+				log.Printf("Failed to bookmark %s: %v", bookmark.Song.Title, err)
+			}
+		}
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
 func (a *App) handleExit() {
+	a.syncOnExit()
 	if a.player != nil {
 		a.player.Cleanup()
 	}
@@ -323,43 +578,58 @@ func (a *App) handleExit() {
 }
 
 func (a *App) performSearch(query string) {
+	if a.searchView == nil {
+		return
+	}
+
+	a.showModal(a.searchView.GetContainer(), 90, 26)
+	a.searchView.Show(query)
+}
+
+// showSearchSongs replaces the song list with search hits, keeping the
+// original list for clearSearch, and plays the song with id playID
+func (a *App) showSearchSongs(songs []domain.Song, playID string) {
+	a.songsMu.Lock()
 	if !a.isSearchMode {
 		a.originalSongs = make([]domain.Song, len(a.totalSongs))
 		copy(a.originalSongs, a.totalSongs)
 		a.isSearchMode = true
 	}
-
-	go func() {
-		songs, err := a.library.SearchSongs(query, 100)
-		if err != nil {
-			a.tviewApp.QueueUpdateDraw(func() {
-				a.statusBar.SetText(fmt.Sprintf("[red]Search failed: %v", err))
-			})
-			return
+	a.totalSongs = make([]domain.Song, len(songs))
+	copy(a.totalSongs, songs)
+	a.songsGen++
+	a.totalPages = (len(a.totalSongs) + a.pageSize - 1) / a.pageSize
+	if a.totalPages == 0 {
+		a.totalPages = 1
+	}
+	a.currentPage = 1
+	a.songsMu.Unlock()
+	a.SortSongs()
+
+	index := -1
+	a.songsMu.RLock()
+	for i, song := range a.totalSongs {
+		if song.ID == playID {
+			index = i
+			break
 		}
+	}
+	a.songsMu.RUnlock()
 
-		a.tviewApp.QueueUpdateDraw(func() {
-			a.songsMu.Lock()
-			a.totalSongs = songs
-			a.totalPages = (len(a.totalSongs) + a.pageSize - 1) / a.pageSize
-			if a.totalPages == 0 {
-				a.totalPages = 1
-			}
-			a.currentPage = 1
-			a.songsMu.Unlock()
-			a.SortSongs()
-			a.renderSongTable()
-			a.updateStatusWithPageInfo()
-			a.searchInput.SetFieldBackgroundColor(tcell.ColorDefault)
-			a.tviewApp.SetFocus(a.songTable)
-		})
-	}()
+	a.renderSongTable()
+	a.updateStatusWithPageInfo()
+	a.searchInput.SetFieldBackgroundColor(tcell.ColorDefault)
+	a.tviewApp.SetFocus(a.songTable)
+	if index >= 0 {
+		go a.playSongAtIndex(index)
+	}
 }
 
 func (a *App) clearSearch() {
 	if a.isSearchMode {
 		a.songsMu.Lock()
 		a.totalSongs = a.originalSongs
+		a.songsGen++
 		a.originalSongs = nil
 		a.isSearchMode = false
 		a.totalPages = (len(a.totalSongs) + a.pageSize - 1) / a.pageSize
@@ -376,10 +646,10 @@ func (a *App) renderSongTable() {
 	for i := a.songTable.GetRowCount() - 1; i > 0; i-- {
 		a.songTable.RemoveRow(i)
 	}
-	a.setupTableHeaders()
+	termWidth := a.getTerminalWidth()
+	a.setupTableHeaders(termWidth)
 	pageData := a.getCurrentPageData()
 	startIndex := (a.currentPage - 1) * a.pageSize
-	termWidth := a.getTerminalWidth()
 
 	currentSong, _, isPlaying, _ := a.state.GetState()
 
@@ -399,13 +669,20 @@ func (a *App) renderSongTable() {
 		trackText := fmt.Sprintf("%d:", globalIndex)
 		if isCurrentTrack {
 			trackText = "▶"
+		} else if a.markedSongs[song.ID] {
+			trackText = "●"
+			trackColor = tcell.ColorLightGreen
 		}
 
 		trackCell := tview.NewTableCell(trackText).
 			SetStyle(rowStyle.Foreground(trackColor)).
 			SetAlign(tview.AlignRight)
 
-		titleCell := tview.NewTableCell(song.Title).
+		title := song.Title
+		if song.Starred != nil {
+			title = "★ " + title
+		}
+		titleCell := tview.NewTableCell(title).
 			SetStyle(rowStyle.Foreground(titleColor)).
 			SetExpansion(1)
 
@@ -420,7 +697,11 @@ func (a *App) renderSongTable() {
 			if isCurrentTrack {
 				durColor = tcell.ColorLightGreen
 			}
-			durationCell := tview.NewTableCell(FormatDuration(song.Duration)).
+			duration := FormatDuration(song.Duration)
+			if song.StreamURL != "" {
+				duration = "live"
+			}
+			durationCell := tview.NewTableCell(duration).
 				SetStyle(rowStyle.Foreground(durColor)).
 				SetAlign(tview.AlignRight)
 			a.songTable.SetCell(row, col, durationCell)
@@ -454,6 +735,13 @@ func (a *App) renderSongTable() {
 				SetStyle(rowStyle.Foreground(tcell.ColorGray)).
 				SetMaxWidth(albumWidth)
 			a.songTable.SetCell(row, col, albumCell)
+			col++
+		}
+
+		if a.cfg.UI.ShowRating && termWidth >= 80 {
+			ratingCell := tview.NewTableCell(FormatRating(song.UserRating)).
+				SetStyle(rowStyle.Foreground(tcell.NewHexColor(0xffb300)))
+			a.songTable.SetCell(row, col, ratingCell)
 		}
 	}
 
@@ -524,7 +812,10 @@ func (a *App) updatePausedDisplay(song *domain.Song) {
 			currentTime := FormatDuration(int(currentPos))
 			totalTime := FormatDuration(int(totalDuration))
 
-			progress := currentPos / totalDuration
+			progress := 0.0 // radio streams have no duration
+			if totalDuration > 0 {
+				progress = currentPos / totalDuration
+			}
 			if progress > 1 {
 				progress = 1
 			} else if progress < 0 {
@@ -546,7 +837,7 @@ func (a *App) updatePausedDisplay(song *domain.Song) {
 			a.progressBar.SetText(bottomBar)
 
 			pausedStatus := fmt.Sprintf("[#ff9800]⏸ PAUSED")
-			a.statusBar.SetText(FormatSongInfo(*song, pausedStatus, spinner, volBar, a.leftPanelTextWidth(), a.serverConnected.Load(), CreatePausedExtras(*song, a.leftPanelTextWidth())))
+			a.statusBar.SetText(FormatSongInfo(*song, pausedStatus, spinner, volBar, a.leftPanelTextWidth(), a.connectionStatus(), CreatePausedExtras(*song, a.leftPanelTextWidth(), a.playingStreamInfo())))
 		}
 	})
 }
@@ -562,11 +853,19 @@ func (a *App) updatePlayingDisplay(song *domain.Song) {
 		return
 	}
 
+	if song.StreamURL != "" {
+		a.updateStreamDisplay(song)
+		return
+	}
+
 	currentPos, totalDuration, err := a.player.GetProgress()
 	if err != nil || totalDuration <= 0 || currentPos < 0 {
 		return
 	}
 
+	a.checkScrobble(song, currentPos, totalDuration)
+	a.checkBookmark(song, currentPos, totalDuration)
+
 	currentTime := FormatDuration(int(currentPos))
 	totalTime := FormatDuration(int(totalDuration))
 
@@ -596,27 +895,32 @@ func (a *App) updatePlayingDisplay(song *domain.Song) {
 			a.progressBar.SetText(bottomBar)
 		}
 		if a.statusBar != nil {
-			a.statusBar.SetText(FormatSongInfo(*song, playingStatus, spinner, volBar, a.leftPanelTextWidth(), a.serverConnected.Load(), CreatePlayingExtras(*song, a.leftPanelTextWidth())))
+			a.statusBar.SetText(FormatSongInfo(*song, playingStatus, spinner, volBar, a.leftPanelTextWidth(), a.connectionStatus(), CreatePlayingExtras(*song, a.leftPanelTextWidth(), a.playingStreamInfo())))
 		}
 	})
 }
 
-func (a *App) showHelp() {
-	if a.helpView == nil {
-		return
-	}
-
+// showModal centers content over the main layout and makes it the root
+func (a *App) showModal(content tview.Primitive, width, height int) {
 	modal := tview.NewFlex().
 		SetDirection(tview.FlexRow).
 		AddItem(nil, 0, 1, false).
 		AddItem(tview.NewFlex().
 			SetDirection(tview.FlexColumn).
 			AddItem(nil, 0, 1, false).
-			AddItem(a.helpView.GetContainer(), 60, 0, true).
-			AddItem(nil, 0, 1, false), 20, 0, true).
+			AddItem(content, width, 0, true).
+			AddItem(nil, 0, 1, false), height, 0, true).
 		AddItem(nil, 0, 1, false)
 
 	a.tviewApp.SetRoot(modal, true)
+}
+
+func (a *App) showHelp() {
+	if a.helpView == nil {
+		return
+	}
+
+	a.showModal(a.helpView.GetContainer(), 60, 20)
 	a.helpView.Show()
 }
 
@@ -625,16 +929,195 @@ func (a *App) showQueue() {
 		return
 	}
 
-	modal := tview.NewFlex().
-		SetDirection(tview.FlexRow).
-		AddItem(nil, 0, 1, false).
-		AddItem(tview.NewFlex().
-			SetDirection(tview.FlexColumn).
-			AddItem(nil, 0, 1, false).
-			AddItem(a.queueView.GetContainer(), 80, 0, true).
-			AddItem(nil, 0, 1, false), 20, 0, true).
-		AddItem(nil, 0, 1, false)
-
-	a.tviewApp.SetRoot(modal, true)
+	a.showModal(a.queueView.GetContainer(), 80, 20)
 	a.queueView.Show()
 }
+
+func (a *App) showPlaylists() {
+	if a.playlistView == nil {
+		return
+	}
+
+	a.showModal(a.playlistView.GetContainer(), 80, 24)
+	a.playlistView.Show()
+}
+
+func (a *App) showBrowser() {
+	if a.browserView == nil {
+		return
+	}
+
+	a.showModal(a.browserView.GetContainer(), 80, 24)
+	a.browserView.Show()
+}
+
+func (a *App) showArtist(id string) {
+	if a.browserView == nil {
+		return
+	}
+
+	a.showModal(a.browserView.GetContainer(), 80, 24)
+	a.browserView.ShowArtist(id)
+}
+
+func (a *App) showAlbum(id string) {
+	if a.browserView == nil {
+		return
+	}
+
+	a.showModal(a.browserView.GetContainer(), 80, 24)
+	a.browserView.ShowAlbum(id)
+}
+
+func (a *App) showSources() {
+	if a.sourceView == nil {
+		return
+	}
+
+	a.showModal(a.sourceView.GetContainer(), 50, len(songSources)+4)
+	a.sourceView.Show()
+}
+
+func (a *App) showLyrics() {
+	if a.lyricsView == nil {
+		return
+	}
+
+	a.showModal(a.lyricsView.GetContainer(), 70, 24)
+	a.lyricsView.Show()
+}
+
+func (a *App) showBookmarks() {
+	if a.bookmarksView == nil {
+		return
+	}
+
+	a.showModal(a.bookmarksView.GetContainer(), 90, 20)
+	a.bookmarksView.Show()
+}
+
+func (a *App) showStations() {
+	if a.stationsView == nil {
+		return
+	}
+
+	a.showModal(a.stationsView.GetContainer(), 80, 20)
+	a.stationsView.Show()
+}
+
+func (a *App) showPodcasts() {
+	if a.podcastView == nil {
+		return
+	}
+
+	a.showModal(a.podcastView.GetContainer(), 90, 24)
+	a.podcastView.Show()
+}
+
+func (a *App) showListeners() {
+	if a.listenersView == nil {
+		return
+	}
+
+	a.showModal(a.listenersView.GetContainer(), 90, 18)
+	a.listenersView.Show()
+}
+
+func (a *App) showDirectories() {
+	if a.directoryView == nil {
+		return
+	}
+
+	a.showModal(a.directoryView.GetContainer(), 90, 28)
+	a.directoryView.Show()
+}
+
+func (a *App) showFolders() {
+	if a.folderView == nil {
+		return
+	}
+
+	a.showModal(a.folderView.GetContainer(), 50, 14)
+	a.folderView.Show()
+}
+
+func (a *App) showServerInfo() {
+	if a.serverView == nil {
+		return
+	}
+
+	a.showModal(a.serverView.GetContainer(), 76, 22)
+	a.serverView.Show()
+}
+
+func (a *App) showGenres() {
+	if a.genreView == nil {
+		return
+	}
+
+	a.showModal(a.genreView.GetContainer(), 60, 24)
+	a.genreView.Show()
+}
+
+// addToPlaylist opens the playlist picker for the marked songs, or for the
+// selected song when nothing is marked.
+func (a *App) addToPlaylist() {
+	if a.playlistView == nil {
+		return
+	}
+
+	songIDs := a.markedSongIDs()
+	if len(songIDs) == 0 {
+		index := a.selectedSongIndex()
+		if index < 0 {
+			return
+		}
+		a.songsMu.RLock()
+		songIDs = []string{a.totalSongs[index].ID}
+		a.songsMu.RUnlock()
+	}
+
+	a.showModal(a.playlistView.GetContainer(), 80, 24)
+	a.playlistView.ShowPicker(songIDs)
+}
+
+// toggleMark marks or unmarks the selected song and moves to the next row
+func (a *App) toggleMark() {
+	index := a.selectedSongIndex()
+	if index < 0 {
+		return
+	}
+	a.songsMu.Lock()
+	id := a.totalSongs[index].ID
+	if a.markedSongs[id] {
+		delete(a.markedSongs, id)
+	} else {
+		a.markedSongs[id] = true
+	}
+	a.songsMu.Unlock()
+
+	row, _ := a.songTable.GetSelection()
+	a.renderSongTable()
+	a.songTable.Select(row, 0)
+	a.moveRowDown()
+}
+
+// markedSongIDs returns the marked songs in list order
+func (a *App) markedSongIDs() []string {
+	a.songsMu.RLock()
+	defer a.songsMu.RUnlock()
+	var ids []string
+	for _, song := range a.totalSongs {
+		if a.markedSongs[song.ID] {
+			ids = append(ids, song.ID)
+		}
+	}
+	return ids
+}
+
+func (a *App) clearMarks() {
+	a.songsMu.Lock()
+	a.markedSongs = make(map[string]bool)
+	a.songsMu.Unlock()
+	a.renderSongTable()
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	connectionCheckInterval = 30 * time.Second
+	reconnectCheckInterval  = 5 * time.Second // while disconnected or a load failed
+)
+
+// setServerAvailable updates the connection indicator. It is called by
+// monitorConnection and by the client's circuit breaker, which notices a
+// dropped server as soon as calls start failing.
+func (a *App) setServerAvailable(available bool) {
+	if a.serverConnected.Swap(available) == available {
+		return
+	}
+	if !available {
+		select {
+		case a.connectionLost <- struct{}{}:
+		default:
+		}
+		return
+	}
+	a.retryFailedLoad()
+}
+
+// connectionStatus is the connection line of the now playing panel. It also
+// shows the progress of a library scan started with U.
+func (a *App) connectionStatus() string {
+	text := "[darkgray]● [gray]Disconnected"
+	if a.serverConnected.Load() {
+		text = "[green]● [gray]Navidrome connected"
+	}
+	if a.scanning.Load() {
+		text += fmt.Sprintf(" [darkgray]· [#ffb300]scanning, %d items", a.scanCount.Load())
+	}
+	return text
+}
+
+// retryFailedLoad reruns a source load that failed while the server was
+// unreachable
+func (a *App) retryFailedLoad() {
+	if a.loadFailed.CompareAndSwap(true, false) {
+		go a.loadMusic()
+	}
+}
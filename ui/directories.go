@@ -0,0 +1,299 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/yhkl-dev/NaviCLI/domain"
+)
+
+// dirNode is the reference of a directory tree node. Directories load their
+// contents the first time they are expanded; song nodes carry the song.
+type dirNode struct {
+	id      string
+	name    string
+	song    *domain.Song
+	songs   []domain.Song // songs directly in the directory, once loaded
+	loaded  bool
+	loading bool
+}
+
+// DirectoryView browses the server's file tree
+type DirectoryView struct {
+	app       *App
+	container *tview.Flex
+	tree      *tview.TreeView
+	root      *tview.TreeNode
+	footer    *tview.TextView
+	isActive  bool
+}
+
+func NewDirectoryView(app *App) *DirectoryView {
+	dv := &DirectoryView{
+		app: app,
+	}
+
+	dv.tree = tview.NewTreeView().
+		SetGraphicsColor(tcell.ColorGray)
+	dv.tree.SetSelectedFunc(dv.open)
+	dv.tree.SetInputCapture(dv.handleKey)
+
+	dv.footer = tview.NewTextView().
+		SetDynamicColors(true)
+
+	dv.container = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(dv.tree, 0, 1, true).
+		AddItem(dv.footer, 1, 0, false)
+
+	dv.container.SetBorder(true).
+		SetTitle(" Directories (ESC to close) ").
+		SetBorderColor(tcell.NewHexColor(0xffb300)).
+		SetTitleColor(tcell.NewHexColor(0xffb300))
+
+	dv.reset()
+	return dv
+}
+
+// Show displays the directory tree, loading its top level on first use
+func (dv *DirectoryView) Show() {
+	dv.isActive = true
+	dv.app.tviewApp.SetFocus(dv.tree)
+	dv.setFooter("[darkgray]ENTER [white]expand / play from here  [darkgray]p [white]play folder  [darkgray]a [white]add to list  [darkgray]ESC [white]close")
+	if ref := dv.root.GetReference().(*dirNode); !ref.loaded {
+		dv.expand(dv.root)
+	}
+}
+
+// Close hides the directory tree
+func (dv *DirectoryView) Close() {
+	dv.isActive = false
+	dv.app.tviewApp.SetRoot(dv.app.rootFlex, true)
+	dv.app.tviewApp.SetFocus(dv.app.songTable)
+}
+
+// IsActive returns whether the directory tree is active
+func (dv *DirectoryView) IsActive() bool {
+	return dv.isActive
+}
+
+// GetContainer returns the directory tree container
+func (dv *DirectoryView) GetContainer() *tview.Flex {
+	return dv.container
+}
+
+// reset drops the loaded tree, e.g. after the music folder changed
+func (dv *DirectoryView) reset() {
+	dv.root = tview.NewTreeNode("Library").
+		SetColor(tcell.NewHexColor(0xffb300)).
+		SetReference(&dirNode{name: "Library"})
+	dv.tree.SetRoot(dv.root).SetCurrentNode(dv.root)
+}
+
+func (dv *DirectoryView) setFooter(text string) {
+	dv.footer.SetText("  " + text)
+}
+
+func (dv *DirectoryView) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyRight:
+		dv.expandCurrent()
+		return nil
+	case tcell.KeyLeft:
+		dv.collapseCurrent()
+		return nil
+	}
+
+	switch event.Rune() {
+	case 'l':
+		dv.expandCurrent()
+	case 'h':
+		dv.collapseCurrent()
+	case 'p':
+		dv.playFolder()
+	case 'a':
+		dv.addToList()
+	default:
+		return event
+	}
+	return nil
+}
+
+// open expands or collapses a directory, or plays its folder from a song
+func (dv *DirectoryView) open(node *tview.TreeNode) {
+	ref := node.GetReference().(*dirNode)
+	if ref.song == nil {
+		if ref.loaded && node.IsExpanded() {
+			node.Collapse()
+		} else {
+			dv.expand(node)
+		}
+		return
+	}
+
+	parent := dv.parentOf(node)
+	if parent == nil {
+		return
+	}
+	dir := parent.GetReference().(*dirNode)
+	for i, song := range dir.songs {
+		if song.ID == ref.song.ID {
+			dv.Close()
+			dv.app.setSongs(dir.songs, "Folder: "+dir.name)
+			go dv.app.playSongAtIndex(i)
+			return
+		}
+	}
+}
+
+func (dv *DirectoryView) expandCurrent() {
+	if node := dv.tree.GetCurrentNode(); node != nil {
+		if ref := node.GetReference().(*dirNode); ref.song == nil {
+			dv.expand(node)
+		}
+	}
+}
+
+// collapseCurrent folds the current directory, or moves to its parent when
+// it is already folded or is a song
+func (dv *DirectoryView) collapseCurrent() {
+	node := dv.tree.GetCurrentNode()
+	if node == nil {
+		return
+	}
+	if ref := node.GetReference().(*dirNode); ref.song == nil && node.IsExpanded() && node != dv.root {
+		node.Collapse()
+		return
+	}
+	if parent := dv.parentOf(node); parent != nil {
+		dv.tree.SetCurrentNode(parent)
+	}
+}
+
+// expand shows a directory's contents, loading them the first time
+func (dv *DirectoryView) expand(node *tview.TreeNode) {
+	ref := node.GetReference().(*dirNode)
+	if ref.loaded {
+		node.Expand()
+		return
+	}
+	if ref.loading {
+		return
+	}
+	ref.loading = true
+	dv.setFooter(fmt.Sprintf("[darkgray]Loading %s...", tview.Escape(ref.name)))
+
+	isRoot := node == dv.root
+	go func() {
+		var dir *domain.Directory
+		var err error
+		if isRoot {
+			dir, err = dv.app.library.GetRootDirectory(dv.app.ctx)
+		} else {
+			dir, err = dv.app.library.GetDirectory(dv.app.ctx, ref.id)
+		}
+		dv.app.tviewApp.QueueUpdateDraw(func() {
+			ref.loading = false
+			if err != nil {
+				dv.setFooter("[red]Loading failed: " + errorText(err))
+				return
+			}
+			ref.loaded = true
+			ref.songs = dir.Songs
+			node.ClearChildren()
+			for _, entry := range dir.Dirs {
+				node.AddChild(tview.NewTreeNode(tview.Escape(entry.Name) + "/").
+					SetColor(tcell.ColorWhite).
+					SetReference(&dirNode{id: entry.ID, name: entry.Name}))
+			}
+			for i := range dir.Songs {
+				song := &dir.Songs[i]
+				node.AddChild(tview.NewTreeNode(fmt.Sprintf("%s (%s)", tview.Escape(song.Title), FormatDuration(song.Duration))).
+					SetColor(tcell.ColorGray).
+					SetReference(&dirNode{id: song.ID, name: song.Title, song: song}))
+			}
+			node.Expand()
+			if dv.isActive {
+				dv.setFooter("[darkgray]ENTER [white]expand / play from here  [darkgray]p [white]play folder  [darkgray]a [white]add to list  [darkgray]ESC [white]close")
+			}
+		})
+	}()
+}
+
+// parentOf finds the parent of node, which tview does not track
+func (dv *DirectoryView) parentOf(node *tview.TreeNode) *tview.TreeNode {
+	var parent *tview.TreeNode
+	dv.root.Walk(func(n, p *tview.TreeNode) bool {
+		if n == node {
+			parent = p
+			return false
+		}
+		return parent == nil
+	})
+	return parent
+}
+
+// selectedSongs loads the songs under the current node, recursively for
+// directories, and hands them to done on the UI goroutine. truncated is set
+// when the folder holds more than ui.fetch_size songs and only those were
+// loaded.
+func (dv *DirectoryView) selectedSongs(done func(name string, songs []domain.Song, truncated bool)) {
+	node := dv.tree.GetCurrentNode()
+	if node == nil {
+		return
+	}
+	ref := node.GetReference().(*dirNode)
+	if ref.song != nil {
+		done(ref.name, []domain.Song{*ref.song}, false)
+		return
+	}
+	if node == dv.root {
+		dv.setFooter("[darkgray]Pick a directory first")
+		return
+	}
+
+	dv.setFooter(fmt.Sprintf("[darkgray]Loading songs under %s...", tview.Escape(ref.name)))
+	go func() {
+		songs, truncated, err := dv.app.library.GetDirectorySongs(dv.app.ctx, ref.id, dv.app.cfg.UI.FetchSize)
+		dv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				dv.setFooter("[red]Loading songs failed: " + errorText(err))
+				return
+			}
+			if len(songs) == 0 {
+				dv.setFooter("[darkgray]No songs under " + tview.Escape(ref.name))
+				return
+			}
+			done(ref.name, songs, truncated)
+		})
+	}()
+}
+
+// playFolder replaces the song list with everything under the current
+// directory and starts playing it
+func (dv *DirectoryView) playFolder() {
+	dv.selectedSongs(func(name string, songs []domain.Song, truncated bool) {
+		if dv.isActive {
+			dv.Close()
+		}
+		label := "Folder: " + name
+		if truncated {
+			label += fmt.Sprintf(" (first %d songs)", len(songs))
+		}
+		dv.app.setSongs(songs, label)
+		go dv.app.playSongAtIndex(0)
+	})
+}
+
+// addToList appends everything under the current node to the song list
+func (dv *DirectoryView) addToList() {
+	dv.selectedSongs(func(name string, songs []domain.Song, truncated bool) {
+		added := dv.app.appendSongs(songs)
+		text := fmt.Sprintf("[green]Added %d song(s) from %s to the list", added, tview.Escape(name))
+		if truncated {
+			text += fmt.Sprintf(" [yellow](stopped after the first %d, raise ui.fetch_size for more)", len(songs))
+		}
+		dv.setFooter(text)
+	})
+}
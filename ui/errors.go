@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"errors"
+
+	"github.com/rivo/tview"
+	"github.com/yhkl-dev/NaviCLI/subsonic"
+)
+
+// errorText turns an error into a message for the status bar and footers.
+// Subsonic API errors are explained by their code, with the server's own
+// message appended; anything else is shown as is.
+func errorText(err error) string {
+	if errors.Is(err, subsonic.ErrUnavailable) {
+		return "the server is unreachable"
+	}
+	var apiErr *subsonic.Error
+	if !errors.As(err, &apiErr) {
+		return tview.Escape(err.Error())
+	}
+
+	var text string
+	switch apiErr.Code {
+	case subsonic.ErrMissingParameter:
+		text = "the server rejected the request (missing parameter)"
+	case subsonic.ErrClientTooOld:
+		text = "the server needs a newer API version, raise client.api_version"
+	case subsonic.ErrServerTooOld:
+		text = "the server is too old for this API version, lower client.api_version"
+	case subsonic.ErrWrongCredentials:
+		text = "wrong credentials, check server.username and server.password"
+	case subsonic.ErrTokenAuthUnsupported:
+		text = "the server does not support token authentication, set server.auth = \"password\""
+	case subsonic.ErrAuthUnsupported:
+		text = "the server does not support this authentication method, change server.auth"
+	case subsonic.ErrConflictingAuth:
+		text = "the server received more than one authentication method, check server.auth and server.api_key"
+	case subsonic.ErrInvalidAPIKey:
+		text = "the API key was rejected, check server.api_key"
+	case subsonic.ErrNotAuthorized:
+		text = "not allowed for this user"
+	case subsonic.ErrTrialExpired:
+		text = "the server's trial period is over"
+	case subsonic.ErrNotFound:
+		text = "not found"
+	default:
+		return tview.Escape(apiErr.Message)
+	}
+	if apiErr.Message != "" {
+		text += " (" + apiErr.Message + ")"
+	}
+	return tview.Escape(text)
+}
@@ -0,0 +1,196 @@
+package ui
+
+import (
+	"log"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/yhkl-dev/NaviCLI/config"
+	"github.com/yhkl-dev/NaviCLI/domain"
+)
+
+// FolderView lets the user limit the library to one music folder
+type FolderView struct {
+	app       *App
+	container *tview.Flex
+	table     *tview.Table
+	footer    *tview.TextView
+	isActive  bool
+	folders   []domain.MusicFolder
+}
+
+func NewFolderView(app *App) *FolderView {
+	fv := &FolderView{
+		app: app,
+	}
+
+	fv.table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false)
+	fv.table.SetSelectedStyle(tcell.StyleDefault.
+		Background(tcell.NewHexColor(0xffb300)).
+		Foreground(tcell.ColorWhite))
+	fv.table.SetSelectedFunc(func(row, column int) {
+		if row == 0 {
+			fv.Close()
+			fv.app.selectMusicFolder(domain.MusicFolder{})
+		} else if row <= len(fv.folders) {
+			fv.Close()
+			fv.app.selectMusicFolder(fv.folders[row-1])
+		}
+	})
+
+	fv.footer = tview.NewTextView().
+		SetDynamicColors(true)
+
+	fv.container = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(fv.table, 0, 1, true).
+		AddItem(fv.footer, 1, 0, false)
+
+	fv.container.SetBorder(true).
+		SetTitle(" Music Folders (ESC to close) ").
+		SetBorderColor(tcell.NewHexColor(0xffb300)).
+		SetTitleColor(tcell.NewHexColor(0xffb300))
+
+	return fv
+}
+
+// Show displays the folder picker
+func (fv *FolderView) Show() {
+	fv.isActive = true
+	fv.app.tviewApp.SetFocus(fv.table)
+	fv.render()
+	fv.loadFolders()
+}
+
+// Close hides the folder picker
+func (fv *FolderView) Close() {
+	fv.isActive = false
+	fv.app.tviewApp.SetRoot(fv.app.rootFlex, true)
+	fv.app.tviewApp.SetFocus(fv.app.songTable)
+}
+
+// IsActive returns whether the folder picker is active
+func (fv *FolderView) IsActive() bool {
+	return fv.isActive
+}
+
+// GetContainer returns the folder picker container
+func (fv *FolderView) GetContainer() *tview.Flex {
+	return fv.container
+}
+
+func (fv *FolderView) loadFolders() {
+	fv.footer.SetText("  [darkgray]Loading folders...")
+	go func() {
+		folders, err := fv.app.library.GetMusicFolders(fv.app.ctx)
+		fv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				fv.footer.SetText("  [red]Loading folders failed: " + errorText(err))
+				return
+			}
+			fv.folders = folders
+			fv.footer.SetText("  [darkgray]ENTER [white]show folder  [darkgray]ESC [white]close")
+			fv.render()
+			fv.selectCurrent()
+		})
+	}()
+}
+
+// selectCurrent moves the selection to the active folder
+func (fv *FolderView) selectCurrent() {
+	current := fv.app.library.MusicFolder()
+	for i, f := range fv.folders {
+		if f.ID == current {
+			fv.table.Select(i+1, 0)
+			return
+		}
+	}
+	fv.table.Select(0, 0)
+}
+
+func (fv *FolderView) render() {
+	fv.table.Clear()
+	current := fv.app.library.MusicFolder()
+	row := func(i int, name string, active bool) {
+		marker := "  "
+		color := tcell.ColorWhite
+		if active {
+			marker = "▶ "
+			color = tcell.ColorLightGreen
+		}
+		fv.table.SetCell(i, 0, tview.NewTableCell(marker+name).SetTextColor(color).SetExpansion(1))
+	}
+	row(0, "All folders", current == "")
+	for i, f := range fv.folders {
+		row(i+1, f.Name, f.ID == current)
+	}
+}
+
+// restoreMusicFolder limits the library to the folder picked in a previous
+// session, or else the one named in the config. It looks the folder up on
+// the server, so it runs before the first load rather than in NewApp.
+func (a *App) restoreMusicFolder() {
+	want := a.cfg.Server.MusicFolder
+	if saved := a.uiState.MusicFolder; saved != "" {
+		want = saved
+	}
+	if want == "" || want == config.AllMusicFolders {
+		return
+	}
+
+	folders, err := a.library.GetMusicFolders(a.ctx)
+	if err != nil {
+		log.Printf("Failed to load music folders, showing all: %v", err)
+		return
+	}
+	for _, f := range folders {
+		if f.ID == want || strings.EqualFold(f.Name, want) {
+			a.setMusicFolder(f)
+			a.tviewApp.QueueUpdateDraw(a.updateSortTitle)
+			return
+		}
+	}
+	log.Printf("Unknown music folder %q, showing all folders", want)
+}
+
+func (a *App) setMusicFolder(folder domain.MusicFolder) {
+	a.library.SetMusicFolder(folder.ID)
+	a.songsMu.Lock()
+	a.folderName = folder.Name
+	a.songsMu.Unlock()
+}
+
+// dropBrowseCaches makes the artist browser and directory tree load afresh
+// once the library they show has changed. A directory tree that is open is
+// kept, so the user does not lose their place.
+func (a *App) dropBrowseCaches() {
+	if a.browserView != nil {
+		a.browserView.indexes = nil
+	}
+	if a.directoryView != nil && !a.directoryView.IsActive() {
+		a.directoryView.reset()
+	}
+}
+
+// selectMusicFolder limits the library to folder, or lifts the limit for the
+// zero folder, reloads the current source and remembers the choice
+func (a *App) selectMusicFolder(folder domain.MusicFolder) {
+	a.setMusicFolder(folder)
+	a.dropBrowseCaches()
+
+	a.uiState.MusicFolder = folder.ID
+	if folder.ID == "" {
+		a.uiState.MusicFolder = config.AllMusicFolders
+	}
+	if err := a.uiState.Save(); err != nil {
+		log.Printf("Failed to save state: %v", err)
+	}
+
+	a.songsMu.RLock()
+	index := a.songSource
+	a.songsMu.RUnlock()
+	a.selectSource(index)
+}
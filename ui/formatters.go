@@ -35,6 +35,17 @@ func FormatDuration(seconds int) string {
 	return fmt.Sprintf("%d:%02d", minutes, secs)
 }
 
+// FormatRating renders a 1-5 rating as stars; unrated songs render empty.
+func FormatRating(rating int) string {
+	if rating <= 0 {
+		return ""
+	}
+	if rating > 5 {
+		rating = 5
+	}
+	return strings.Repeat("★", rating) + strings.Repeat("☆", 5-rating)
+}
+
 // ---- Geek paused extras ----
 
 func CreateOscilloscope(width int) string {
@@ -163,20 +174,22 @@ func CreateGoDebug(track domain.Song) string {
 	)
 }
 
-func CreatePlayingExtras(track domain.Song, width int) string {
+func CreatePlayingExtras(track domain.Song, width int, stream string) string {
 	parts := []string{
 		CreateOscilloscope(width),
 		"",
 		CreateAudioSpecs(track, width),
+		"  " + stream,
 	}
 	return strings.Join(parts, "\n")
 }
 
-func CreatePausedExtras(track domain.Song, width int) string {
+func CreatePausedExtras(track domain.Song, width int, stream string) string {
 	parts := []string{
 		CreateOscilloscope(width),
 		"",
 		CreateAudioSpecs(track, width),
+		"  " + stream,
 		"",
 		CreateGoDebug(track),
 	}
@@ -185,7 +198,7 @@ func CreatePausedExtras(track domain.Song, width int) string {
 
 // ---- Song info / progress bar / welcome ----
 
-func FormatSongInfo(track domain.Song, status string, spinner string, volumeBar string, panelWidth int, connected bool, extra string) string {
+func FormatSongInfo(track domain.Song, status string, spinner string, volumeBar string, panelWidth int, connection string, extra string) string {
 	duration := FormatDuration(track.Duration)
 
 	trackInfo := ""
@@ -199,11 +212,6 @@ func FormatSongInfo(track domain.Song, status string, spinner string, volumeBar
 	}
 	sep := strings.Repeat("─", sepWidth)
 
-	connDot := "[darkgray]● [gray]Disconnected"
-	if connected {
-		connDot = "[green]● [gray]Navidrome connected"
-	}
-
 	extraSection := ""
 	if extra != "" {
 		extraSection = extra + "\n"
@@ -229,7 +237,7 @@ func FormatSongInfo(track domain.Song, status string, spinner string, volumeBar
 		sep,
 		extraSection,
 		volumeBar,
-		connDot,
+		connection,
 		status,
 	)
 }
@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/yhkl-dev/NaviCLI/domain"
+)
+
+// GenreView lets the user pick a genre to load into the song list
+type GenreView struct {
+	app       *App
+	container *tview.Flex
+	table     *tview.Table
+	footer    *tview.TextView
+	isActive  bool
+	genres    []domain.Genre
+}
+
+func NewGenreView(app *App) *GenreView {
+	gv := &GenreView{
+		app: app,
+	}
+
+	gv.table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	gv.table.SetSelectedStyle(tcell.StyleDefault.
+		Background(tcell.NewHexColor(0xffb300)).
+		Foreground(tcell.ColorWhite))
+	gv.table.SetSelectedFunc(func(row, column int) {
+		if row > 0 && row <= len(gv.genres) {
+			gv.Close()
+			gv.app.selectGenre(gv.genres[row-1].Name)
+		}
+	})
+
+	gv.footer = tview.NewTextView().
+		SetDynamicColors(true)
+
+	gv.container = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(gv.table, 0, 1, true).
+		AddItem(gv.footer, 1, 0, false)
+
+	gv.container.SetBorder(true).
+		SetTitle(" Genres (ESC to close) ").
+		SetBorderColor(tcell.NewHexColor(0xffb300)).
+		SetTitleColor(tcell.NewHexColor(0xffb300))
+
+	return gv
+}
+
+// Show displays the genre picker
+func (gv *GenreView) Show() {
+	gv.isActive = true
+	gv.app.tviewApp.SetFocus(gv.table)
+	gv.render()
+	gv.loadGenres()
+}
+
+// Close hides the genre picker
+func (gv *GenreView) Close() {
+	gv.isActive = false
+	gv.app.tviewApp.SetRoot(gv.app.rootFlex, true)
+	gv.app.tviewApp.SetFocus(gv.app.songTable)
+}
+
+// IsActive returns whether the genre picker is active
+func (gv *GenreView) IsActive() bool {
+	return gv.isActive
+}
+
+// GetContainer returns the genre picker container
+func (gv *GenreView) GetContainer() *tview.Flex {
+	return gv.container
+}
+
+func (gv *GenreView) loadGenres() {
+	gv.footer.SetText("  [darkgray]Loading genres...")
+	go func() {
+		genres, err := gv.app.library.GetGenres(gv.app.ctx)
+		gv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				gv.footer.SetText("  [red]Loading genres failed: " + errorText(err))
+				return
+			}
+			sort.Slice(genres, func(i, j int) bool {
+				return strings.ToLower(genres[i].Name) < strings.ToLower(genres[j].Name)
+			})
+			gv.genres = genres
+			gv.footer.SetText("  [darkgray]ENTER [white]load genre  [darkgray]ESC [white]close")
+			gv.render()
+			gv.selectCurrent()
+		})
+	}()
+}
+
+// selectCurrent moves the selection to the active genre, if any
+func (gv *GenreView) selectCurrent() {
+	gv.app.songsMu.RLock()
+	current := gv.app.genre
+	gv.app.songsMu.RUnlock()
+	for i, g := range gv.genres {
+		if g.Name == current {
+			gv.table.Select(i+1, 0)
+			return
+		}
+	}
+	gv.table.Select(1, 0)
+}
+
+func (gv *GenreView) render() {
+	gv.table.Clear()
+	headerStyle := tcell.StyleDefault.Foreground(tcell.NewHexColor(0xffb300)).Attributes(tcell.AttrBold)
+	gv.table.SetCell(0, 0, tview.NewTableCell("Genre").SetStyle(headerStyle).SetExpansion(1))
+	gv.table.SetCell(0, 1, tview.NewTableCell("Songs").SetStyle(headerStyle).SetAlign(tview.AlignRight))
+	gv.table.SetCell(0, 2, tview.NewTableCell("Albums").SetStyle(headerStyle).SetAlign(tview.AlignRight))
+
+	rowStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	for i, g := range gv.genres {
+		row := i + 1
+		gv.table.SetCell(row, 0, tview.NewTableCell(g.Name).SetStyle(rowStyle).SetExpansion(1))
+		gv.table.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("%d", g.SongCount)).
+			SetStyle(rowStyle.Foreground(tcell.ColorGray)).SetAlign(tview.AlignRight))
+		gv.table.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%d", g.AlbumCount)).
+			SetStyle(rowStyle.Foreground(tcell.ColorGray)).SetAlign(tview.AlignRight))
+	}
+}
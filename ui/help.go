@@ -44,9 +44,32 @@ func NewHelpView(app *App) *HelpView {
   [white]G[-]           Go to last page
 
 [#ffb300]Search & Info:[-]
-  [white]/[-]           Open search
-  [white]s[-]           Sort: Random / Title / Artist / Album
-  [white]S[-]           Source: Random / Albums
+  [white]/[-]           Search artists, albums and songs
+  [white]b[-]           Browse artists → albums → tracks (ENTER open, p play, ESC back)
+  [white]s[-]           Sort: Original / Title / Artist / Album
+  [white]S[-]           Pick source: Random, Albums, Newest, Recently/Most Played,
+              Highest Rated, Starred, By Year, By Genre, Radio...
+  [white]e[-]           Pick a genre to load
+  [white]y[-]           Show lyrics (synced lyrics follow playback)
+  [white]r / R[-]       Start radio from selected track / its artist
+  [white]E[-]           Toggle auto-extend with similar songs
+  [white]B[-]           Bookmarks (ENTER resume, a bookmark playing song, d delete)
+  [white]I[-]           Internet radio stations (ENTER play, n new, e edit, d delete)
+  [white]c[-]           Podcasts (n subscribe, d unsubscribe, R refresh, D download)
+  [white]t[-]           Switch transcoding profile (applies from the next track)
+  [white]i[-]           Server info and supported features
+  [white]F[-]           Pick the music folder (library) to show
+  [white]d[-]           Browse directories (p play folder, a add to list)
+  [white]U[-]           Scan the server library for new files
+  [white]w[-]           Who's listening (ENTER play, a add to list)
+  [white]f[-]           Star/unstar selected song
+  [white]1-5 / 0[-]     Rate selected song / clear rating
+
+[#ffb300]Playlists:[-]
+  [white]L[-]           Browse playlists (ENTER load, n new, r rename, e edit, d delete)
+  [white]m[-]           Mark/unmark selected song
+  [white]a[-]           Add marked (or selected) songs to a playlist
+  [white]K / J[-]       Move track up/down while editing a playlist
   [white]?[-]           Show this help panel
   [white]q / Q[-]       Show playback queue
 
@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/yhkl-dev/NaviCLI/domain"
+)
+
+// ListenersView lists what everyone on the server is listening to
+type ListenersView struct {
+	app       *App
+	container *tview.Flex
+	table     *tview.Table
+	footer    *tview.TextView
+	isActive  bool
+	entries   []domain.NowPlaying
+}
+
+func NewListenersView(app *App) *ListenersView {
+	lv := &ListenersView{
+		app: app,
+	}
+
+	lv.table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	lv.table.SetSelectedStyle(tcell.StyleDefault.
+		Background(tcell.NewHexColor(0xffb300)).
+		Foreground(tcell.ColorWhite))
+	lv.table.SetSelectedFunc(func(row, column int) {
+		lv.play(row - 1)
+	})
+	lv.table.SetInputCapture(lv.handleKey)
+
+	lv.footer = tview.NewTextView().
+		SetDynamicColors(true)
+
+	lv.container = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(lv.table, 0, 1, true).
+		AddItem(lv.footer, 1, 0, false)
+
+	lv.container.SetBorder(true).
+		SetTitle(" Who's Listening (ESC/w to close) ").
+		SetBorderColor(tcell.NewHexColor(0xffb300)).
+		SetTitleColor(tcell.NewHexColor(0xffb300))
+
+	return lv
+}
+
+// Show displays the panel and loads what is playing
+func (lv *ListenersView) Show() {
+	lv.isActive = true
+	lv.app.tviewApp.SetFocus(lv.table)
+	lv.render()
+	lv.load()
+}
+
+// Close hides the panel
+func (lv *ListenersView) Close() {
+	lv.isActive = false
+	lv.app.tviewApp.SetRoot(lv.app.rootFlex, true)
+	lv.app.tviewApp.SetFocus(lv.app.songTable)
+}
+
+// IsActive returns whether the panel is active
+func (lv *ListenersView) IsActive() bool {
+	return lv.isActive
+}
+
+// GetContainer returns the panel container
+func (lv *ListenersView) GetContainer() *tview.Flex {
+	return lv.container
+}
+
+func (lv *ListenersView) setFooter(text string) {
+	lv.footer.SetText("  " + text)
+}
+
+func (lv *ListenersView) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Rune() {
+	case 'a':
+		row, _ := lv.table.GetSelection()
+		lv.enqueue(row - 1)
+	case 'r':
+		lv.load()
+	default:
+		return event
+	}
+	return nil
+}
+
+func (lv *ListenersView) load() {
+	lv.setFooter("[darkgray]Loading...")
+	go func() {
+		entries, err := lv.app.library.GetNowPlaying(lv.app.ctx)
+		lv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				lv.setFooter("[red]Loading failed: " + errorText(err))
+				return
+			}
+			lv.entries = entries
+			lv.render()
+			lv.table.Select(1, 0)
+			lv.setFooter("[darkgray]ENTER [white]play  [darkgray]a [white]add to list  [darkgray]r [white]refresh  [darkgray]ESC [white]close")
+		})
+	}()
+}
+
+func (lv *ListenersView) render() {
+	lv.table.Clear()
+	headerStyle := tcell.StyleDefault.Foreground(tcell.NewHexColor(0xffb300)).Attributes(tcell.AttrBold)
+	for col, title := range []string{"User", "Track", "Player", "Started"} {
+		cell := tview.NewTableCell(title).SetStyle(headerStyle)
+		if col == 1 {
+			cell.SetExpansion(1)
+		}
+		lv.table.SetCell(0, col, cell)
+	}
+
+	if len(lv.entries) == 0 {
+		lv.table.SetCell(1, 1, tview.NewTableCell("Nobody is listening right now").
+			SetTextColor(tcell.ColorGray).SetSelectable(false))
+		return
+	}
+
+	rowStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	for i, e := range lv.entries {
+		row := i + 1
+		player := e.PlayerName
+		if player == "" {
+			player = "unknown"
+		}
+		lv.table.SetCell(row, 0, tview.NewTableCell(tview.Escape(e.Username)).
+			SetStyle(rowStyle.Foreground(tcell.ColorLightGreen)))
+		lv.table.SetCell(row, 1, tview.NewTableCell(tview.Escape(e.Song.Title)+" [gray]— "+tview.Escape(e.Song.Artist)).
+			SetStyle(rowStyle).SetExpansion(1))
+		lv.table.SetCell(row, 2, tview.NewTableCell(tview.Escape(player)).
+			SetStyle(rowStyle.Foreground(tcell.ColorGray)).SetMaxWidth(20))
+		lv.table.SetCell(row, 3, tview.NewTableCell(minutesAgo(e.MinutesAgo)).
+			SetStyle(rowStyle.Foreground(tcell.ColorGray)).SetAlign(tview.AlignRight))
+	}
+}
+
+func minutesAgo(minutes int) string {
+	switch {
+	case minutes <= 0:
+		return "just now"
+	case minutes == 1:
+		return "1 min ago"
+	}
+	return fmt.Sprintf("%d min ago", minutes)
+}
+
+// play loads the listed tracks into the song list and plays the one at index
+func (lv *ListenersView) play(index int) {
+	if index < 0 || index >= len(lv.entries) {
+		return
+	}
+	songs := make([]domain.Song, len(lv.entries))
+	for i, e := range lv.entries {
+		songs[i] = e.Song
+	}
+	lv.Close()
+	lv.app.setSongs(songs, "Now Playing")
+	go lv.app.playSongAtIndex(index)
+}
+
+// enqueue appends the track at index to the song list
+func (lv *ListenersView) enqueue(index int) {
+	if index < 0 || index >= len(lv.entries) {
+		return
+	}
+	song := lv.entries[index].Song
+	if lv.app.appendSongs([]domain.Song{song}) == 0 {
+		lv.setFooter("[darkgray]" + tview.Escape(song.Title) + " is already in the list")
+		return
+	}
+	lv.setFooter("[green]Added " + tview.Escape(song.Title) + " to the list")
+}
@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/yhkl-dev/NaviCLI/domain"
+)
+
+// lyricsRefresh is how often the lyrics view checks the playback position
+const lyricsRefresh = 250 * time.Millisecond
+
+// LyricsView shows the playing song's lyrics. Synced lyrics follow playback
+// with the current line highlighted; unsynced lyrics are plain scrollable text.
+type LyricsView struct {
+	app       *App
+	container *tview.Flex
+	text      *tview.TextView
+	footer    *tview.TextView
+	isActive  bool
+	stop      chan struct{}
+}
+
+func NewLyricsView(app *App) *LyricsView {
+	lv := &LyricsView{
+		app: app,
+	}
+
+	lv.text = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetTextAlign(tview.AlignCenter)
+
+	lv.footer = tview.NewTextView().
+		SetDynamicColors(true)
+
+	lv.container = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(lv.text, 0, 1, true).
+		AddItem(lv.footer, 1, 0, false)
+
+	lv.container.SetBorder(true).
+		SetTitle(" Lyrics (ESC/y to close) ").
+		SetBorderColor(tcell.NewHexColor(0xffb300)).
+		SetTitleColor(tcell.NewHexColor(0xffb300))
+
+	return lv
+}
+
+// Show displays the lyrics view and starts following playback
+func (lv *LyricsView) Show() {
+	lv.isActive = true
+	lv.text.Clear()
+	lv.app.tviewApp.SetFocus(lv.text)
+	lv.stop = make(chan struct{})
+	go lv.follow(lv.stop)
+}
+
+// Close hides the lyrics view
+func (lv *LyricsView) Close() {
+	lv.isActive = false
+	close(lv.stop)
+	lv.app.tviewApp.SetRoot(lv.app.rootFlex, true)
+	lv.app.tviewApp.SetFocus(lv.app.songTable)
+}
+
+// IsActive returns whether the lyrics view is active
+func (lv *LyricsView) IsActive() bool {
+	return lv.isActive
+}
+
+// GetContainer returns the lyrics view container
+func (lv *LyricsView) GetContainer() *tview.Flex {
+	return lv.container
+}
+
+func (lv *LyricsView) setFooter(text string) {
+	lv.footer.SetText("  " + text)
+}
+
+// follow runs until stop is closed. It loads lyrics whenever the playing song
+// changes and moves the highlight of synced lyrics along with playback.
+func (lv *LyricsView) follow(stop chan struct{}) {
+	ticker := time.NewTicker(lyricsRefresh)
+	defer ticker.Stop()
+
+	var songID string
+	var lyrics *domain.Lyrics
+	var idle bool
+	current := -1
+
+	for {
+		song, _, _, _ := lv.app.state.GetState()
+		switch {
+		case song == nil:
+			if !idle {
+				idle = true
+				songID, lyrics = "", nil
+				lv.app.tviewApp.QueueUpdateDraw(func() {
+					lv.text.SetText("[darkgray]Nothing is playing")
+					lv.setFooter("")
+				})
+			}
+		case song.ID != songID:
+			// -2 matches no line, so synced lyrics render on the next tick
+			idle = false
+			songID, current = song.ID, -2
+			loading := *song
+			lv.app.tviewApp.QueueUpdateDraw(func() {
+				lv.text.SetText("[darkgray]Loading lyrics...")
+				lv.setFooter(fmt.Sprintf("[white]%s [darkgray]· %s", tview.Escape(loading.Title), tview.Escape(loading.Artist)))
+			})
+
+			var err error
+			lyrics, err = lv.app.library.GetLyrics(lv.app.ctx, loading)
+			loaded := lyrics
+			lv.app.tviewApp.QueueUpdateDraw(func() {
+				switch {
+				case err != nil:
+					lv.text.SetText("[red]Loading lyrics failed: " + errorText(err))
+				case loaded == nil:
+					lv.text.SetText("[darkgray]No lyrics for this song")
+				case !loaded.Synced:
+					lv.text.SetWrap(true)
+					lv.text.SetText(renderLyrics(loaded, -1))
+					lv.text.ScrollToBeginning()
+				}
+			})
+		case lyrics != nil && lyrics.Synced:
+			pos, _, err := lv.app.player.GetProgress()
+			if err != nil {
+				break
+			}
+			line := currentLyricLine(lyrics, time.Duration(pos*float64(time.Second)))
+			if line != current {
+				current = line
+				synced := lyrics
+				lv.app.tviewApp.QueueUpdateDraw(func() {
+					lv.text.SetWrap(false)
+					lv.text.SetText(renderLyrics(synced, line))
+					_, _, _, height := lv.text.GetInnerRect()
+					lv.text.ScrollTo(max(0, line-height/2), 0)
+				})
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		case <-lv.app.ctx.Done():
+			return
+		}
+	}
+}
+
+// currentLyricLine returns the index of the last line starting at or before
+// pos, or -1 before the first line
+func currentLyricLine(lyrics *domain.Lyrics, pos time.Duration) int {
+	current := -1
+	for i, line := range lyrics.Lines {
+		if line.Start > pos {
+			break
+		}
+		current = i
+	}
+	return current
+}
+
+// renderLyrics formats lyrics for the text view, highlighting line current
+func renderLyrics(lyrics *domain.Lyrics, current int) string {
+	var b strings.Builder
+	for i, line := range lyrics.Lines {
+		text := tview.Escape(line.Text)
+		switch {
+		case !lyrics.Synced:
+			b.WriteString("[white]" + text)
+		case i == current:
+			b.WriteString("[#ffb300::b]" + text + "[-::-]")
+		default:
+			b.WriteString("[darkgray]" + text)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,519 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/yhkl-dev/NaviCLI/domain"
+)
+
+type playlistMode int
+
+const (
+	playlistBrowse playlistMode = iota // list playlists, ENTER loads one
+	playlistPick                       // choose a playlist to add songs to
+	playlistEdit                       // reorder/remove tracks of one playlist
+)
+
+type PlaylistView struct {
+	app       *App
+	container *tview.Flex
+	table     *tview.Table
+	input     *tview.InputField
+	footer    *tview.TextView
+	isActive  bool
+
+	mode          playlistMode
+	playlists     []domain.Playlist
+	pendingSongs  []string         // songs to add in pick mode
+	editing       *domain.Playlist // playlist being edited in edit mode
+	dirty         bool             // editing has changes that are not saved
+	confirmDelete bool
+	confirmLeave  bool // ESC was pressed with unsaved edits
+}
+
+func NewPlaylistView(app *App) *PlaylistView {
+	pv := &PlaylistView{
+		app: app,
+	}
+
+	pv.table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	pv.table.SetSelectedStyle(tcell.StyleDefault.
+		Background(tcell.NewHexColor(0xffb300)).
+		Foreground(tcell.ColorWhite))
+	pv.table.SetInputCapture(pv.handleKey)
+
+	pv.input = tview.NewInputField().
+		SetFieldBackgroundColor(tcell.ColorDefault)
+
+	pv.footer = tview.NewTextView().
+		SetDynamicColors(true)
+
+	pv.container = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(pv.table, 0, 1, true).
+		AddItem(pv.input, 0, 0, false).
+		AddItem(pv.footer, 1, 0, false)
+
+	pv.container.SetBorder(true).
+		SetBorderColor(tcell.NewHexColor(0xffb300)).
+		SetTitleColor(tcell.NewHexColor(0xffb300))
+
+	return pv
+}
+
+// Show opens the view listing all playlists
+func (pv *PlaylistView) Show() {
+	pv.open(playlistBrowse, nil)
+}
+
+// ShowPicker opens the view to choose a playlist that songIDs are added to
+func (pv *PlaylistView) ShowPicker(songIDs []string) {
+	pv.open(playlistPick, songIDs)
+}
+
+func (pv *PlaylistView) open(mode playlistMode, songIDs []string) {
+	pv.isActive = true
+	pv.mode = mode
+	pv.pendingSongs = songIDs
+	pv.editing = nil
+	pv.dirty = false
+	pv.confirmDelete = false
+	pv.confirmLeave = false
+	pv.updateTitle()
+	pv.app.tviewApp.SetFocus(pv.table)
+	pv.loadPlaylists()
+}
+
+// Close hides the playlist view
+func (pv *PlaylistView) Close() {
+	pv.isActive = false
+	pv.hideInput()
+	pv.app.tviewApp.SetRoot(pv.app.rootFlex, true)
+	pv.app.tviewApp.SetFocus(pv.app.songTable)
+}
+
+// IsActive returns whether the playlist view is active
+func (pv *PlaylistView) IsActive() bool {
+	return pv.isActive
+}
+
+// GetContainer returns the playlist view container
+func (pv *PlaylistView) GetContainer() *tview.Flex {
+	return pv.container
+}
+
+func (pv *PlaylistView) updateTitle() {
+	switch pv.mode {
+	case playlistPick:
+		pv.container.SetTitle(fmt.Sprintf(" Add %d song(s) to playlist (ESC to close) ", len(pv.pendingSongs)))
+		pv.setFooter("[darkgray]ENTER [white]add  [darkgray]ESC [white]cancel")
+	case playlistEdit:
+		pv.container.SetTitle(fmt.Sprintf(" Edit: %s (ESC to go back) ", pv.editing.Name))
+		pv.setFooter("[darkgray]K/J [white]move up/down  [darkgray]x [white]remove  [darkgray]w [white]save")
+	default:
+		pv.container.SetTitle(" Playlists (ESC to close) ")
+		pv.setFooter("[darkgray]ENTER [white]load  [darkgray]n [white]new  [darkgray]r [white]rename  [darkgray]e [white]edit  [darkgray]d [white]delete")
+	}
+}
+
+func (pv *PlaylistView) setFooter(text string) {
+	pv.footer.SetText("  " + text)
+}
+
+func (pv *PlaylistView) setError(action string, err error) {
+	pv.setFooter(fmt.Sprintf("[red]%s failed: %s", action, errorText(err)))
+}
+
+func (pv *PlaylistView) loadPlaylists() {
+	pv.setFooter("[darkgray]Loading playlists...")
+	go func() {
+		playlists, err := pv.app.library.GetPlaylists(pv.app.ctx)
+		pv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				pv.setError("Loading playlists", err)
+				return
+			}
+			pv.playlists = playlists
+			pv.updateTitle()
+			pv.renderPlaylists()
+		})
+	}()
+}
+
+func (pv *PlaylistView) clearRows() {
+	for i := pv.table.GetRowCount() - 1; i >= 0; i-- {
+		pv.table.RemoveRow(i)
+	}
+}
+
+func (pv *PlaylistView) renderPlaylists() {
+	pv.clearRows()
+	headerStyle := tcell.StyleDefault.Foreground(tcell.NewHexColor(0xffb300)).Attributes(tcell.AttrBold)
+	pv.table.SetCell(0, 0, tview.NewTableCell("Name").SetStyle(headerStyle).SetExpansion(1))
+	pv.table.SetCell(0, 1, tview.NewTableCell("Songs").SetStyle(headerStyle).SetAlign(tview.AlignRight))
+	pv.table.SetCell(0, 2, tview.NewTableCell("Duration").SetStyle(headerStyle).SetAlign(tview.AlignRight))
+	pv.table.SetCell(0, 3, tview.NewTableCell("Owner").SetStyle(headerStyle))
+
+	row := 1
+	if pv.mode == playlistPick {
+		pv.table.SetCell(row, 0, tview.NewTableCell("+ New playlist").SetTextColor(tcell.ColorLightGreen))
+		row++
+	}
+
+	rowStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	for _, p := range pv.playlists {
+		pv.table.SetCell(row, 0, tview.NewTableCell(p.Name).SetStyle(rowStyle).SetExpansion(1))
+		pv.table.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("%d", p.SongCount)).
+			SetStyle(rowStyle.Foreground(tcell.ColorGray)).SetAlign(tview.AlignRight))
+		pv.table.SetCell(row, 2, tview.NewTableCell(FormatDuration(p.Duration)).
+			SetStyle(rowStyle.Foreground(tcell.ColorGray)).SetAlign(tview.AlignRight))
+		pv.table.SetCell(row, 3, tview.NewTableCell(p.Owner).
+			SetStyle(rowStyle.Foreground(tcell.ColorGray)).SetMaxWidth(16))
+		row++
+	}
+
+	if row == 1 {
+		pv.table.SetCell(1, 0, tview.NewTableCell("No playlists").SetTextColor(tcell.ColorGray))
+	}
+	pv.table.Select(1, 0)
+}
+
+func (pv *PlaylistView) renderTracks() {
+	pv.clearRows()
+	headerStyle := tcell.StyleDefault.Foreground(tcell.NewHexColor(0xffb300)).Attributes(tcell.AttrBold)
+	pv.table.SetCell(0, 0, tview.NewTableCell("#").SetStyle(headerStyle).SetAlign(tview.AlignRight))
+	pv.table.SetCell(0, 1, tview.NewTableCell("Title").SetStyle(headerStyle).SetExpansion(1))
+	pv.table.SetCell(0, 2, tview.NewTableCell("Artist").SetStyle(headerStyle))
+	pv.table.SetCell(0, 3, tview.NewTableCell("Duration").SetStyle(headerStyle).SetAlign(tview.AlignRight))
+
+	rowStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	for i, song := range pv.editing.Songs {
+		row := i + 1
+		pv.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", row)).
+			SetStyle(rowStyle.Foreground(tcell.NewHexColor(0xffb300))).SetAlign(tview.AlignRight))
+		pv.table.SetCell(row, 1, tview.NewTableCell(song.Title).SetStyle(rowStyle).SetExpansion(1))
+		pv.table.SetCell(row, 2, tview.NewTableCell(song.Artist).
+			SetStyle(rowStyle.Foreground(tcell.ColorGray)).SetMaxWidth(20))
+		pv.table.SetCell(row, 3, tview.NewTableCell(FormatDuration(song.Duration)).
+			SetStyle(rowStyle.Foreground(tcell.ColorGray)).SetAlign(tview.AlignRight))
+	}
+}
+
+// selectedPlaylist returns the playlist on the selected row, or nil for the
+// "+ New playlist" row and empty lists.
+func (pv *PlaylistView) selectedPlaylist() *domain.Playlist {
+	row, _ := pv.table.GetSelection()
+	index := row - 1
+	if pv.mode == playlistPick {
+		index--
+	}
+	if index < 0 || index >= len(pv.playlists) {
+		return nil
+	}
+	return &pv.playlists[index]
+}
+
+func (pv *PlaylistView) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	if pv.confirmDelete {
+		pv.confirmDelete = false
+		if event.Rune() == 'y' || event.Rune() == 'Y' {
+			pv.deleteSelected()
+		} else {
+			pv.updateTitle()
+		}
+		return nil
+	}
+	if pv.confirmLeave {
+		pv.confirmLeave = false
+		switch event.Rune() {
+		case 'w', 'W':
+			pv.saveEdit(true)
+		case 'y', 'Y':
+			pv.leaveEdit()
+		default:
+			pv.updateTitle()
+		}
+		return nil
+	}
+
+	switch pv.mode {
+	case playlistEdit:
+		return pv.handleEditKey(event)
+	case playlistPick:
+		return pv.handlePickKey(event)
+	default:
+		return pv.handleBrowseKey(event)
+	}
+}
+
+func (pv *PlaylistView) handleBrowseKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyEscape:
+		pv.Close()
+		return nil
+	case tcell.KeyEnter:
+		if p := pv.selectedPlaylist(); p != nil {
+			pv.loadIntoLibrary(*p)
+		}
+		return nil
+	}
+
+	switch event.Rune() {
+	case 'n':
+		pv.prompt("New playlist: ", "", func(name string) {
+			pv.create(name, nil)
+		})
+	case 'r':
+		if p := pv.selectedPlaylist(); p != nil {
+			id := p.ID
+			pv.prompt("Rename to: ", p.Name, func(name string) {
+				pv.rename(id, name)
+			})
+		}
+	case 'd':
+		if p := pv.selectedPlaylist(); p != nil {
+			pv.confirmDelete = true
+			pv.setFooter(fmt.Sprintf("[red]Delete %q? [white]y[darkgray] to confirm, any other key to cancel", p.Name))
+		}
+	case 'e':
+		if p := pv.selectedPlaylist(); p != nil {
+			pv.edit(p.ID)
+		}
+	default:
+		return event
+	}
+	return nil
+}
+
+func (pv *PlaylistView) handlePickKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyEscape:
+		pv.Close()
+		return nil
+	case tcell.KeyEnter:
+		p := pv.selectedPlaylist()
+		if p == nil {
+			pv.prompt("New playlist: ", "", func(name string) {
+				pv.create(name, pv.pendingSongs)
+			})
+			return nil
+		}
+		pv.addPending(*p)
+		return nil
+	}
+	return event
+}
+
+func (pv *PlaylistView) handleEditKey(event *tcell.EventKey) *tcell.EventKey {
+	if event.Key() == tcell.KeyEscape {
+		if pv.dirty {
+			pv.confirmLeave = true
+			pv.setFooter("[red]Unsaved changes. [white]w[darkgray] to save, [white]y[darkgray] to discard, any other key to keep editing")
+			return nil
+		}
+		pv.leaveEdit()
+		return nil
+	}
+
+	row, _ := pv.table.GetSelection()
+	index := row - 1
+	songs := pv.editing.Songs
+	switch event.Rune() {
+	case 'K':
+		if index > 0 && index < len(songs) {
+			songs[index-1], songs[index] = songs[index], songs[index-1]
+			pv.dirty = true
+			pv.renderTracks()
+			pv.table.Select(row-1, 0)
+		}
+	case 'J':
+		if index >= 0 && index < len(songs)-1 {
+			songs[index], songs[index+1] = songs[index+1], songs[index]
+			pv.dirty = true
+			pv.renderTracks()
+			pv.table.Select(row+1, 0)
+		}
+	case 'x':
+		if index >= 0 && index < len(songs) {
+			pv.editing.Songs = append(songs[:index], songs[index+1:]...)
+			pv.dirty = true
+			pv.renderTracks()
+			if row > len(pv.editing.Songs) {
+				row = len(pv.editing.Songs)
+			}
+			pv.table.Select(row, 0)
+		}
+	case 'w':
+		pv.saveEdit(false)
+	default:
+		return event
+	}
+	return nil
+}
+
+// prompt shows the inline input below the table and calls done with the
+// entered text on ENTER. ESC cancels.
+func (pv *PlaylistView) prompt(label, initial string, done func(text string)) {
+	pv.input.SetLabel("[#ffb300]" + label).SetText(initial)
+	pv.input.SetDoneFunc(func(key tcell.Key) {
+		text := strings.TrimSpace(pv.input.GetText())
+		pv.hideInput()
+		pv.app.tviewApp.SetFocus(pv.table)
+		if key == tcell.KeyEnter && text != "" {
+			done(text)
+		}
+	})
+	pv.container.ResizeItem(pv.input, 1, 0)
+	pv.app.tviewApp.SetFocus(pv.input)
+}
+
+func (pv *PlaylistView) hideInput() {
+	pv.container.ResizeItem(pv.input, 0, 0)
+}
+
+func (pv *PlaylistView) create(name string, songIDs []string) {
+	pv.setFooter("[darkgray]Creating playlist...")
+	go func() {
+		_, err := pv.app.library.CreatePlaylist(pv.app.ctx, name, songIDs)
+		pv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				pv.setError("Create", err)
+				return
+			}
+			if pv.mode == playlistPick {
+				pv.app.clearMarks()
+				pv.Close()
+				pv.app.statusBar.SetText(fmt.Sprintf("[green]Created playlist %q with %d song(s)", name, len(songIDs)))
+				return
+			}
+			pv.loadPlaylists()
+		})
+	}()
+}
+
+func (pv *PlaylistView) addPending(p domain.Playlist) {
+	songIDs := pv.pendingSongs
+	pv.setFooter("[darkgray]Adding songs...")
+	go func() {
+		err := pv.app.library.AddToPlaylist(pv.app.ctx, p.ID, songIDs)
+		pv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				pv.setError("Add", err)
+				return
+			}
+			pv.app.clearMarks()
+			pv.Close()
+			pv.app.statusBar.SetText(fmt.Sprintf("[green]Added %d song(s) to %q", len(songIDs), p.Name))
+		})
+	}()
+}
+
+func (pv *PlaylistView) rename(id, name string) {
+	go func() {
+		err := pv.app.library.RenamePlaylist(pv.app.ctx, id, name)
+		pv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				pv.setError("Rename", err)
+				return
+			}
+			pv.loadPlaylists()
+		})
+	}()
+}
+
+func (pv *PlaylistView) deleteSelected() {
+	p := pv.selectedPlaylist()
+	if p == nil {
+		return
+	}
+	id := p.ID
+	go func() {
+		err := pv.app.library.DeletePlaylist(pv.app.ctx, id)
+		pv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				pv.setError("Delete", err)
+				return
+			}
+			pv.loadPlaylists()
+		})
+	}()
+}
+
+func (pv *PlaylistView) edit(id string) {
+	pv.setFooter("[darkgray]Loading tracks...")
+	go func() {
+		playlist, err := pv.app.library.GetPlaylist(pv.app.ctx, id)
+		pv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				pv.setError("Loading playlist", err)
+				return
+			}
+			pv.mode = playlistEdit
+			pv.editing = playlist
+			pv.dirty = false
+			pv.updateTitle()
+			pv.renderTracks()
+			pv.table.Select(1, 0)
+		})
+	}()
+}
+
+// leaveEdit goes back from edit mode to the playlist list, dropping unsaved
+// edits
+func (pv *PlaylistView) leaveEdit() {
+	pv.mode = playlistBrowse
+	pv.editing = nil
+	pv.dirty = false
+	pv.updateTitle()
+	pv.renderPlaylists()
+}
+
+// saveEdit replaces the songs of the edited playlist with the edited list,
+// then goes back to the playlist list if leave is set
+func (pv *PlaylistView) saveEdit(leave bool) {
+	playlist := pv.editing
+	songIDs := make([]string, len(playlist.Songs))
+	for i, song := range playlist.Songs {
+		songIDs[i] = song.ID
+	}
+	pv.setFooter("[darkgray]Saving...")
+	go func() {
+		err := pv.app.library.ReplacePlaylistSongs(pv.app.ctx, playlist.ID, songIDs)
+		pv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				pv.setError("Save", err)
+				return
+			}
+			if pv.editing != playlist {
+				return
+			}
+			pv.dirty = false
+			if leave {
+				pv.leaveEdit()
+				return
+			}
+			pv.setFooter(fmt.Sprintf("[green]Saved %d track(s)", len(songIDs)))
+		})
+	}()
+}
+
+func (pv *PlaylistView) loadIntoLibrary(p domain.Playlist) {
+	pv.setFooter("[darkgray]Loading tracks...")
+	go func() {
+		playlist, err := pv.app.library.GetPlaylist(pv.app.ctx, p.ID)
+		pv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				pv.setError("Loading playlist", err)
+				return
+			}
+			pv.Close()
+			pv.app.setSongs(playlist.Songs, "Playlist: "+playlist.Name)
+		})
+	}()
+}
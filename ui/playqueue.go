@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/yhkl-dev/NaviCLI/domain"
+)
+
+// maxSavedQueue caps the songs sent to savePlayQueue. Longer lists are saved
+// starting at the playing song.
+const maxSavedQueue = 200
+
+// playQueueSnapshot returns the song list, playing song and position to save.
+// ok is false when nothing or a radio stream is playing.
+func (a *App) playQueueSnapshot() (songIDs []string, current string, position time.Duration, ok bool) {
+	song, index, _, _ := a.state.GetState()
+	if song == nil || song.StreamURL != "" {
+		return nil, "", 0, false
+	}
+
+	a.songsMu.RLock()
+	songs := a.totalSongs
+	if len(songs) > maxSavedQueue && index >= 0 && index < len(songs) {
+		songs = songs[index:min(index+maxSavedQueue, len(songs))]
+	}
+	songIDs = make([]string, 0, len(songs))
+	for _, s := range songs {
+		songIDs = append(songIDs, s.ID)
+	}
+	a.songsMu.RUnlock()
+
+	if pos, _, err := a.player.GetProgress(); err == nil {
+		position = time.Duration(pos * float64(time.Second))
+	}
+	return songIDs, song.ID, position, true
+}
+
+// syncPlayQueue saves the queue to the server. Saves are serialized so an
+// older snapshot never lands after a newer one.
+func (a *App) syncPlayQueue(ctx context.Context) {
+	a.playQueueMu.Lock()
+	defer a.playQueueMu.Unlock()
+
+	songIDs, current, position, ok := a.playQueueSnapshot()
+	if !ok {
+		return
+	}
+	if err := a.library.SavePlayQueue(ctx, songIDs, current, position); err != nil {
+		log.Printf("Failed to save play queue: %v", err)
+	}
+}
+
+// savePlayQueue saves the queue in the background
+func (a *App) savePlayQueue() {
+	go a.syncPlayQueue(a.ctx)
+}
+
+// offerResume asks whether to resume the queue saved on the server. It runs
+// after the initial source load so resuming isn't overwritten by it.
+func (a *App) offerResume() {
+	queue, err := a.library.GetPlayQueue(a.ctx)
+	if err != nil {
+		log.Printf("Failed to load saved play queue: %v", err)
+		return
+	}
+	if queue == nil || len(queue.Songs) == 0 {
+		return
+	}
+
+	a.tviewApp.QueueUpdateDraw(func() {
+		if song, _, _, _ := a.state.GetState(); song != nil {
+			return // already playing something
+		}
+		a.showModal(a.resumeView.GetContainer(), 60, 7)
+		a.resumeView.Show(queue)
+	})
+}
+
+// resumePlayQueue loads a saved queue and plays its current song from the
+// saved position
+func (a *App) resumePlayQueue(queue *domain.PlayQueue) {
+	index := 0
+	for i, song := range queue.Songs {
+		if song.ID == queue.Current {
+			index = i
+			break
+		}
+	}
+	a.setSongs(queue.Songs, "Saved Queue")
+	a.goToSongPage(index)
+	go a.playSongFrom(index, queue.Position.Seconds())
+}
+
+// ResumeView offers to resume the play queue saved on the server
+type ResumeView struct {
+	app       *App
+	container *tview.Flex
+	text      *tview.TextView
+	isActive  bool
+	queue     *domain.PlayQueue
+}
+
+func NewResumeView(app *App) *ResumeView {
+	rv := &ResumeView{
+		app: app,
+	}
+
+	rv.text = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true)
+	rv.text.SetInputCapture(rv.handleKey)
+
+	rv.container = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(rv.text, 0, 1, true)
+
+	rv.container.SetBorder(true).
+		SetTitle(" Resume Queue ").
+		SetBorderColor(tcell.NewHexColor(0xffb300)).
+		SetTitleColor(tcell.NewHexColor(0xffb300))
+
+	return rv
+}
+
+// Show displays the offer for queue
+func (rv *ResumeView) Show(queue *domain.PlayQueue) {
+	rv.isActive = true
+	rv.queue = queue
+
+	title := "the first song"
+	for _, song := range queue.Songs {
+		if song.ID == queue.Current {
+			title = song.Title
+			break
+		}
+	}
+	from := ""
+	if queue.ChangedBy != "" {
+		from = " from " + tview.Escape(queue.ChangedBy)
+	}
+	rv.text.SetText(fmt.Sprintf("\n  Resume the saved queue%s?\n  [white]%s [darkgray]at %s · %d songs\n\n  [darkgray]ENTER/y [white]resume  [darkgray]ESC/n [white]dismiss",
+		from, tview.Escape(title), FormatDuration(int(queue.Position.Seconds())), len(queue.Songs)))
+	rv.app.tviewApp.SetFocus(rv.text)
+}
+
+// Close hides the resume offer
+func (rv *ResumeView) Close() {
+	rv.isActive = false
+	rv.app.tviewApp.SetRoot(rv.app.rootFlex, true)
+	rv.app.tviewApp.SetFocus(rv.app.songTable)
+}
+
+// IsActive returns whether the resume offer is shown
+func (rv *ResumeView) IsActive() bool {
+	return rv.isActive
+}
+
+// GetContainer returns the resume view container
+func (rv *ResumeView) GetContainer() *tview.Flex {
+	return rv.container
+}
+
+func (rv *ResumeView) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	switch {
+	case event.Key() == tcell.KeyEnter || event.Rune() == 'y' || event.Rune() == 'Y':
+		rv.Close()
+		rv.app.resumePlayQueue(rv.queue)
+	case event.Key() == tcell.KeyEscape || event.Rune() == 'n' || event.Rune() == 'N':
+		rv.Close()
+	}
+	return nil
+}
@@ -0,0 +1,449 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/yhkl-dev/NaviCLI/domain"
+)
+
+// newestEpisodes is how many episodes the "Newest episodes" row lists
+const newestEpisodes = 50
+
+type podcastLevel int
+
+const (
+	podcastChannels podcastLevel = iota
+	podcastEpisodes
+)
+
+// PodcastView lists subscribed channels and their episodes with played state
+type PodcastView struct {
+	app       *App
+	container *tview.Flex
+	table     *tview.Table
+	input     *tview.InputField
+	footer    *tview.TextView
+	isActive  bool
+
+	level         podcastLevel
+	channels      []domain.PodcastChannel
+	title         string // channel shown at the episode level
+	episodes      []domain.PodcastEpisode
+	channelRow    int // selection to restore when going back up
+	confirmDelete bool
+}
+
+func NewPodcastView(app *App) *PodcastView {
+	pv := &PodcastView{
+		app: app,
+	}
+
+	pv.table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	pv.table.SetSelectedStyle(tcell.StyleDefault.
+		Background(tcell.NewHexColor(0xffb300)).
+		Foreground(tcell.ColorWhite))
+	pv.table.SetInputCapture(pv.handleKey)
+
+	pv.input = tview.NewInputField().
+		SetFieldBackgroundColor(tcell.ColorDefault)
+
+	pv.footer = tview.NewTextView().
+		SetDynamicColors(true)
+
+	pv.container = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(pv.table, 0, 1, true).
+		AddItem(pv.input, 0, 0, false).
+		AddItem(pv.footer, 1, 0, false)
+
+	pv.container.SetBorder(true).
+		SetBorderColor(tcell.NewHexColor(0xffb300)).
+		SetTitleColor(tcell.NewHexColor(0xffb300))
+
+	return pv
+}
+
+// Show displays the channel list
+func (pv *PodcastView) Show() {
+	pv.isActive = true
+	pv.level = podcastChannels
+	pv.confirmDelete = false
+	pv.app.tviewApp.SetFocus(pv.table)
+	pv.render()
+	pv.loadChannels()
+}
+
+// Close hides the podcast view
+func (pv *PodcastView) Close() {
+	pv.isActive = false
+	pv.hideInput()
+	pv.app.tviewApp.SetRoot(pv.app.rootFlex, true)
+	pv.app.tviewApp.SetFocus(pv.app.songTable)
+}
+
+// IsActive returns whether the podcast view is active
+func (pv *PodcastView) IsActive() bool {
+	return pv.isActive
+}
+
+// GetContainer returns the podcast view container
+func (pv *PodcastView) GetContainer() *tview.Flex {
+	return pv.container
+}
+
+func (pv *PodcastView) setFooter(text string) {
+	pv.footer.SetText("  " + text)
+}
+
+func (pv *PodcastView) showKeys() {
+	if pv.level == podcastEpisodes {
+		pv.setFooter("[darkgray]ENTER [white]play/resume  [darkgray]D [white]download  [darkgray]ESC [white]back")
+		return
+	}
+	pv.setFooter("[darkgray]ENTER [white]episodes  [darkgray]n [white]subscribe  [darkgray]d [white]unsubscribe  [darkgray]R [white]refresh")
+}
+
+func (pv *PodcastView) loadChannels() {
+	pv.setFooter("[darkgray]Loading podcasts...")
+	go func() {
+		channels, err := pv.app.library.GetPodcasts(pv.app.ctx)
+		pv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				pv.setFooter("[red]Loading podcasts failed: " + errorText(err))
+				return
+			}
+			pv.channels = channels
+			if pv.level == podcastChannels {
+				pv.render()
+				pv.showKeys()
+			}
+		})
+	}()
+}
+
+// loadEpisodes shows the episodes of a channel, or the newest episodes across
+// all channels when channel is nil. Bookmarks are fetched alongside so
+// episodes in progress show their position.
+func (pv *PodcastView) loadEpisodes(channel *domain.PodcastChannel) {
+	pv.setFooter("[darkgray]Loading episodes...")
+	go func() {
+		var episodes []domain.PodcastEpisode
+		title := "Newest episodes"
+		var err error
+		if channel == nil {
+			episodes, err = pv.app.library.GetNewestPodcasts(pv.app.ctx, newestEpisodes)
+		} else {
+			var ch *domain.PodcastChannel
+			ch, err = pv.app.library.GetPodcastChannel(pv.app.ctx, channel.ID)
+			if err == nil {
+				episodes, title = ch.Episodes, ch.Title
+			}
+		}
+		if err == nil {
+			if bookmarks, bErr := pv.app.library.GetBookmarks(pv.app.ctx); bErr == nil {
+				pv.app.rememberBookmarks(bookmarks)
+			}
+		}
+
+		pv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				pv.setFooter("[red]Loading episodes failed: " + errorText(err))
+				return
+			}
+			pv.level = podcastEpisodes
+			pv.title = title
+			pv.episodes = episodes
+			pv.render()
+			pv.table.Select(1, 0)
+			pv.showKeys()
+		})
+	}()
+}
+
+func (pv *PodcastView) render() {
+	for i := pv.table.GetRowCount() - 1; i >= 0; i-- {
+		pv.table.RemoveRow(i)
+	}
+	if pv.level == podcastEpisodes {
+		pv.container.SetTitle(fmt.Sprintf(" %s (ESC to go back) ", pv.title))
+		pv.renderEpisodes()
+		return
+	}
+	pv.container.SetTitle(" Podcasts (ESC to close) ")
+	pv.renderChannels()
+}
+
+func (pv *PodcastView) renderChannels() {
+	headerStyle := tcell.StyleDefault.Foreground(tcell.NewHexColor(0xffb300)).Attributes(tcell.AttrBold)
+	pv.table.SetCell(0, 0, tview.NewTableCell("Channel").SetStyle(headerStyle).SetExpansion(1))
+	pv.table.SetCell(0, 1, tview.NewTableCell("Status").SetStyle(headerStyle))
+
+	rowStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	pv.table.SetCell(1, 0, tview.NewTableCell("Newest episodes").SetStyle(rowStyle.Foreground(tcell.NewHexColor(0xffb300))))
+	pv.table.SetCell(1, 1, tview.NewTableCell(""))
+	for i, ch := range pv.channels {
+		status := ch.Status
+		if ch.Error != "" {
+			status = "error: " + ch.Error
+		}
+		pv.table.SetCell(i+2, 0, tview.NewTableCell(ch.Title).SetStyle(rowStyle).SetExpansion(1))
+		pv.table.SetCell(i+2, 1, tview.NewTableCell(status).SetStyle(rowStyle.Foreground(tcell.ColorGray)).SetMaxWidth(30))
+	}
+}
+
+func (pv *PodcastView) renderEpisodes() {
+	headerStyle := tcell.StyleDefault.Foreground(tcell.NewHexColor(0xffb300)).Attributes(tcell.AttrBold)
+	for col, title := range []string{"", "Published", "Episode", "Duration"} {
+		cell := tview.NewTableCell(title).SetStyle(headerStyle)
+		if col == 2 {
+			cell.SetExpansion(1)
+		}
+		pv.table.SetCell(0, col, cell)
+	}
+
+	rowStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	for i, e := range pv.episodes {
+		row := i + 1
+		state, stateColor := pv.episodeState(e)
+		titleStyle := rowStyle
+		if e.Played() || !e.Downloaded() {
+			titleStyle = rowStyle.Foreground(tcell.ColorGray)
+		}
+		published := ""
+		if !e.PublishDate.IsZero() {
+			published = e.PublishDate.Local().Format("2006-01-02")
+		}
+		pv.table.SetCell(row, 0, tview.NewTableCell(state).SetStyle(rowStyle.Foreground(stateColor)))
+		pv.table.SetCell(row, 1, tview.NewTableCell(published).SetStyle(rowStyle.Foreground(tcell.ColorGray)))
+		pv.table.SetCell(row, 2, tview.NewTableCell(e.Title).SetStyle(titleStyle).SetExpansion(1))
+		pv.table.SetCell(row, 3, tview.NewTableCell(FormatDuration(e.Song.Duration)).
+			SetStyle(rowStyle.Foreground(tcell.ColorGray)).SetAlign(tview.AlignRight))
+	}
+	if len(pv.episodes) == 0 {
+		pv.table.SetCell(1, 2, tview.NewTableCell("No episodes").SetTextColor(tcell.ColorGray))
+	}
+}
+
+// episodeState returns the marker for an episode: not downloaded, in
+// progress (with its bookmark), played or new
+func (pv *PodcastView) episodeState(e domain.PodcastEpisode) (string, tcell.Color) {
+	switch {
+	case !e.Downloaded():
+		return "↓ " + e.Status, tcell.ColorGray
+	case pv.app.bookmarkPosition(e.Song.ID) > 0:
+		return "◐ " + FormatDuration(int(pv.app.bookmarkPosition(e.Song.ID).Seconds())), tcell.NewHexColor(0xffb300)
+	case e.Played():
+		return "✓ played", tcell.ColorGray
+	default:
+		return "● new", tcell.ColorLightGreen
+	}
+}
+
+// selectedChannel returns the channel on the selected row. ok is true with a
+// nil channel for the "Newest episodes" row.
+func (pv *PodcastView) selectedChannel() (channel *domain.PodcastChannel, ok bool) {
+	row, _ := pv.table.GetSelection()
+	switch {
+	case row == 1:
+		return nil, true
+	case row >= 2 && row-2 < len(pv.channels):
+		return &pv.channels[row-2], true
+	}
+	return nil, false
+}
+
+func (pv *PodcastView) selectedEpisode() *domain.PodcastEpisode {
+	row, _ := pv.table.GetSelection()
+	if row <= 0 || row > len(pv.episodes) {
+		return nil
+	}
+	return &pv.episodes[row-1]
+}
+
+func (pv *PodcastView) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	if pv.confirmDelete {
+		pv.confirmDelete = false
+		if event.Rune() == 'y' || event.Rune() == 'Y' {
+			pv.unsubscribe()
+		} else {
+			pv.showKeys()
+		}
+		return nil
+	}
+
+	switch event.Key() {
+	case tcell.KeyEscape, tcell.KeyBackspace, tcell.KeyBackspace2, tcell.KeyLeft:
+		if pv.level == podcastEpisodes {
+			pv.level = podcastChannels
+			pv.render()
+			pv.table.Select(pv.channelRow, 0)
+			pv.showKeys()
+		} else if event.Key() == tcell.KeyEscape {
+			pv.Close()
+		}
+		return nil
+	case tcell.KeyEnter:
+		pv.open()
+		return nil
+	}
+
+	if pv.level == podcastEpisodes {
+		if event.Rune() == 'D' {
+			pv.download()
+			return nil
+		}
+		return event
+	}
+
+	switch event.Rune() {
+	case 'n':
+		pv.prompt("Feed URL: ", func(feedURL string) { pv.subscribe(feedURL) })
+	case 'd':
+		if channel, ok := pv.selectedChannel(); ok && channel != nil {
+			pv.confirmDelete = true
+			pv.setFooter(fmt.Sprintf("[red]Unsubscribe from %q? [white]y[darkgray] to confirm, any other key to cancel", channel.Title))
+		}
+	case 'R':
+		pv.refresh()
+	default:
+		return event
+	}
+	return nil
+}
+
+func (pv *PodcastView) open() {
+	if pv.level == podcastChannels {
+		if channel, ok := pv.selectedChannel(); ok {
+			pv.channelRow, _ = pv.table.GetSelection()
+			pv.loadEpisodes(channel)
+		}
+		return
+	}
+	pv.play()
+}
+
+// play loads the downloaded episodes into the song list and plays the
+// selected one, resuming from its bookmark
+func (pv *PodcastView) play() {
+	selected := pv.selectedEpisode()
+	if selected == nil {
+		return
+	}
+	if !selected.Downloaded() {
+		pv.setFooter("[darkgray]Not downloaded yet, press [white]D[darkgray] to download it on the server")
+		return
+	}
+
+	var songs []domain.Song
+	index := 0
+	for _, e := range pv.episodes {
+		if !e.Downloaded() {
+			continue
+		}
+		if e.ID == selected.ID {
+			index = len(songs)
+		}
+		song := e.Song
+		song.Title = e.Title
+		if song.Artist == "" {
+			song.Artist = pv.title
+		}
+		songs = append(songs, song)
+	}
+
+	pv.Close()
+	pv.app.setSongs(songs, "Podcast: "+pv.title)
+	pv.app.goToSongPage(index)
+	go pv.app.playSongAtIndex(index)
+}
+
+func (pv *PodcastView) download() {
+	e := pv.selectedEpisode()
+	if e == nil {
+		return
+	}
+	if e.Downloaded() {
+		pv.setFooter("[darkgray]Already downloaded")
+		return
+	}
+	id, title := e.ID, e.Title
+	go func() {
+		err := pv.app.library.DownloadPodcastEpisode(pv.app.ctx, id)
+		pv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				pv.setFooter("[red]Download failed: " + errorText(err))
+				return
+			}
+			pv.setFooter(fmt.Sprintf("[green]Downloading %q on the server", title))
+		})
+	}()
+}
+
+func (pv *PodcastView) prompt(label string, done func(text string)) {
+	pv.input.SetLabel("[#ffb300]" + label).SetText("")
+	pv.input.SetDoneFunc(func(key tcell.Key) {
+		text := strings.TrimSpace(pv.input.GetText())
+		pv.hideInput()
+		pv.app.tviewApp.SetFocus(pv.table)
+		if key == tcell.KeyEnter && text != "" {
+			done(text)
+		}
+	})
+	pv.container.ResizeItem(pv.input, 1, 0)
+	pv.app.tviewApp.SetFocus(pv.input)
+}
+
+func (pv *PodcastView) hideInput() {
+	pv.container.ResizeItem(pv.input, 0, 0)
+}
+
+func (pv *PodcastView) subscribe(feedURL string) {
+	pv.setFooter("[darkgray]Subscribing...")
+	go func() {
+		err := pv.app.library.CreatePodcastChannel(pv.app.ctx, feedURL)
+		pv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				pv.setFooter("[red]Subscribing failed: " + errorText(err))
+				return
+			}
+			pv.loadChannels()
+		})
+	}()
+}
+
+func (pv *PodcastView) unsubscribe() {
+	channel, ok := pv.selectedChannel()
+	if !ok || channel == nil {
+		return
+	}
+	id := channel.ID
+	go func() {
+		err := pv.app.library.DeletePodcastChannel(pv.app.ctx, id)
+		pv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				pv.setFooter("[red]Unsubscribing failed: " + errorText(err))
+				return
+			}
+			pv.loadChannels()
+		})
+	}()
+}
+
+func (pv *PodcastView) refresh() {
+	go func() {
+		err := pv.app.library.RefreshPodcasts(pv.app.ctx)
+		pv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				pv.setFooter("[red]Refresh failed: " + errorText(err))
+				return
+			}
+			pv.setFooter("[green]The server is checking for new episodes")
+		})
+	}()
+}
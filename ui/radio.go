@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/yhkl-dev/NaviCLI/domain"
+)
+
+const (
+	radioSize       = 50  // songs fetched when starting a radio
+	topSongsSize    = 10  // top songs leading an artist radio
+	extendSize      = 20  // similar songs fetched per auto-extend
+	extendThreshold = 3   // extend when this few songs are left after the current one
+	recentHistory   = 200 // recently played song IDs skipped by auto-extend
+)
+
+// startTrackRadio replaces the song list with the selected song followed by
+// songs similar to it
+func (a *App) startTrackRadio() {
+	index := a.selectedSongIndex()
+	if index < 0 {
+		return
+	}
+	a.songsMu.RLock()
+	seed := a.totalSongs[index]
+	a.songsMu.RUnlock()
+
+	a.statusBar.SetText(fmt.Sprintf("[darkgray]Starting radio from %s...", seed.Title))
+	go func() {
+		similar, err := a.library.GetSimilarSongs(a.ctx, seed.ID, radioSize)
+		a.startRadio("Radio: "+seed.Title, append([]domain.Song{seed}, similar...), err)
+	}()
+}
+
+// startArtistRadio replaces the song list with the selected song's artist's
+// top songs followed by songs from similar artists
+func (a *App) startArtistRadio() {
+	index := a.selectedSongIndex()
+	if index < 0 {
+		return
+	}
+	a.songsMu.RLock()
+	seed := a.totalSongs[index]
+	a.songsMu.RUnlock()
+
+	a.statusBar.SetText(fmt.Sprintf("[darkgray]Starting radio from %s...", seed.Artist))
+	go func() {
+		songs, err := a.library.GetTopSongs(a.ctx, seed.Artist, topSongsSize)
+		if err == nil && seed.ArtistID != "" {
+			var similar []domain.Song
+			similar, err = a.library.GetSimilarSongs(a.ctx, seed.ArtistID, radioSize)
+			songs = append(songs, similar...)
+		}
+		a.startRadio("Artist Radio: "+seed.Artist, songs, err)
+	}()
+}
+
+// startRadio loads a radio list and plays it from the top. It is called off
+// the UI goroutine.
+func (a *App) startRadio(label string, songs []domain.Song, err error) {
+	songs = uniqueSongs(songs, nil)
+	a.tviewApp.QueueUpdateDraw(func() {
+		if err != nil {
+			a.statusBar.SetText("[red]Starting radio failed: " + errorText(err))
+			return
+		}
+		if len(songs) == 0 {
+			a.statusBar.SetText("[darkgray]The server found no similar songs")
+			return
+		}
+		a.setSongs(songs, label)
+		go a.playSongAtIndex(0)
+	})
+}
+
+func (a *App) toggleAutoExtend() {
+	enabled := !a.autoExtend.Load()
+	a.autoExtend.Store(enabled)
+	if enabled {
+		a.statusBar.SetText("[#ffb300]Auto-extend on: similar songs are added near the end of the list")
+		a.extendIfNeeded()
+	} else {
+		a.statusBar.SetText("[darkgray]Auto-extend off")
+	}
+}
+
+// rememberPlayed records a song for auto-extend to skip
+func (a *App) rememberPlayed(id string) {
+	a.recentMu.Lock()
+	defer a.recentMu.Unlock()
+	a.recentlyPlayed = append(a.recentlyPlayed, id)
+	if len(a.recentlyPlayed) > recentHistory {
+		a.recentlyPlayed = a.recentlyPlayed[len(a.recentlyPlayed)-recentHistory:]
+	}
+}
+
+// extendIfNeeded appends songs similar to the playing one when auto-extend is
+// on and playback is within extendThreshold songs of the end of the list.
+// Songs already in the list or played recently are skipped, and so are
+// podcast episodes and radio stations, which have no similar songs.
+func (a *App) extendIfNeeded() {
+	if !a.autoExtend.Load() {
+		return
+	}
+	song, index, _, _ := a.state.GetState()
+	if song == nil || song.IsPodcast || song.StreamURL != "" {
+		return
+	}
+	a.songsMu.RLock()
+	remaining := len(a.totalSongs) - index - 1
+	gen := a.songsGen
+	a.songsMu.RUnlock()
+	if remaining >= extendThreshold || !a.extending.CompareAndSwap(false, true) {
+		return
+	}
+
+	seed := *song
+	go func() {
+		defer a.extending.Store(false)
+
+		similar, err := a.library.GetSimilarSongs(a.ctx, seed.ID, extendSize)
+		if err != nil {
+			a.tviewApp.QueueUpdateDraw(func() {
+				a.statusBar.SetText("[red]Auto-extend failed: " + errorText(err))
+			})
+			return
+		}
+
+		a.recentMu.Lock()
+		skip := slices.Clone(a.recentlyPlayed)
+		a.recentMu.Unlock()
+		a.songsMu.Lock()
+		if a.songsGen != gen {
+			// another list was loaded meanwhile; the songs belong to the old one
+			a.songsMu.Unlock()
+			return
+		}
+		for _, s := range a.totalSongs {
+			skip = append(skip, s.ID)
+		}
+		added := uniqueSongs(similar, skip)
+		a.totalSongs = append(a.totalSongs, added...)
+		a.totalPages = (len(a.totalSongs) + a.pageSize - 1) / a.pageSize
+		a.songsMu.Unlock()
+
+		if len(added) == 0 {
+			return
+		}
+		a.tviewApp.QueueUpdateDraw(func() {
+			a.renderSongTable()
+			a.updateStatusWithPageInfo()
+		})
+	}()
+}
+
+// uniqueSongs drops repeated songs and songs whose ID is in skip, keeping the
+// first occurrence
+func uniqueSongs(songs []domain.Song, skip []string) []domain.Song {
+	seen := make(map[string]bool, len(skip)+len(songs))
+	for _, id := range skip {
+		seen[id] = true
+	}
+	result := make([]domain.Song, 0, len(songs))
+	for _, song := range songs {
+		if seen[song.ID] {
+			continue
+		}
+		seen[song.ID] = true
+		result = append(result, song)
+	}
+	return result
+}
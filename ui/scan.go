@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+const scanPollInterval = 2 * time.Second
+
+// startScan asks the server to rescan its library and follows the scan in
+// the background
+func (a *App) startScan() {
+	if !a.scanning.CompareAndSwap(false, true) {
+		a.statusBar.SetText("[darkgray]A library scan is already running")
+		return
+	}
+	a.scanCount.Store(0)
+	a.statusBar.SetText("[#ffb300]Starting library scan...")
+	go a.followScan()
+}
+
+// followScan polls the scan status until the scan is over, then reloads the
+// current source so new albums show up. Lists loaded by other means, such as
+// a playlist, are left alone.
+func (a *App) followScan() {
+	defer a.scanning.Store(false)
+
+	status, err := a.library.StartScan(a.ctx)
+	for err == nil {
+		a.scanCount.Store(int64(status.Count))
+		a.tviewApp.QueueUpdateDraw(a.updateStatusWithPageInfo)
+
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-time.After(scanPollInterval):
+		}
+		// startScan may answer before the scan has begun, so only a polled
+		// status counts as finished
+		status, err = a.library.GetScanStatus(a.ctx)
+		if err == nil && !status.Scanning {
+			break
+		}
+	}
+	if a.ctx.Err() != nil {
+		return
+	}
+	a.scanning.Store(false)
+
+	a.tviewApp.QueueUpdateDraw(func() {
+		if err != nil {
+			a.statusBar.SetText("[red]Library scan failed: " + errorText(err))
+			return
+		}
+		a.dropBrowseCaches()
+		a.songsMu.RLock()
+		reload := a.sourceLabel == ""
+		a.songsMu.RUnlock()
+		if reload {
+			go a.loadMusic()
+		}
+		a.statusBar.SetText(fmt.Sprintf("[green]Library scan finished: %d items", status.Count))
+	})
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"log"
+	"time"
+
+	"github.com/yhkl-dev/NaviCLI/domain"
+	"github.com/yhkl-dev/NaviCLI/scrobble"
+	"github.com/yhkl-dev/NaviCLI/subsonic"
+)
+
+// startScrobble tells the server a track just started and arms the play
+// submission for it.
+func (a *App) startScrobble(song domain.Song) {
+	a.scrobbleMu.Lock()
+	a.scrobbleSongID = song.ID
+	a.scrobbleStarted = time.Now()
+	a.scrobbleDone = false
+	a.scrobbleMu.Unlock()
+
+	go func() {
+		if err := a.library.Scrobble(a.ctx, song.ID, time.Now(), false); err != nil {
+			log.Printf("now playing update failed for %s: %v", song.ID, err)
+		}
+	}()
+}
+
+// checkScrobble submits the play once the current track has passed the
+// scrobble threshold. It is called from the progress ticker.
+func (a *App) checkScrobble(song *domain.Song, currentPos, totalDuration float64) {
+	duration := song.Duration
+	if duration <= 0 {
+		duration = int(totalDuration)
+	}
+
+	a.scrobbleMu.Lock()
+	if a.scrobbleDone || a.scrobbleSongID != song.ID || currentPos < scrobble.Threshold(duration) {
+		a.scrobbleMu.Unlock()
+		return
+	}
+	a.scrobbleDone = true
+	sub := scrobble.Submission{SongID: song.ID, PlayedAt: a.scrobbleStarted}
+	a.scrobbleMu.Unlock()
+
+	go func() {
+		if err := a.library.Scrobble(a.ctx, sub.SongID, sub.PlayedAt, true); err != nil {
+			log.Printf("scrobble failed for %s, queued for retry: %v", sub.SongID, err)
+			if err := a.scrobbles.Add(sub); err != nil {
+				log.Printf("failed to queue scrobble: %v", err)
+			}
+		}
+	}()
+}
+
+// flushScrobbles retries submissions that failed while the server was away.
+func (a *App) flushScrobbles() {
+	if a.scrobbles.Len() == 0 {
+		return
+	}
+	sent, err := a.scrobbles.Flush(func(s scrobble.Submission) error {
+		return a.library.Scrobble(a.ctx, s.SongID, s.PlayedAt, true)
+	}, func(err error) bool {
+		// keep what failed because the server is away or we are shutting down
+		return subsonic.IsUnavailable(err) || a.ctx.Err() != nil
+	})
+	if err != nil {
+		log.Printf("failed to save scrobble queue: %v", err)
+	}
+	if sent > 0 {
+		log.Printf("submitted %d queued scrobbles", sent)
+	}
+}
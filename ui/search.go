@@ -0,0 +1,291 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/yhkl-dev/NaviCLI/domain"
+	"github.com/yhkl-dev/NaviCLI/library"
+)
+
+// searchPageSize is how many hits of each kind one search request fetches
+const searchPageSize = 20
+
+type searchSection int
+
+const (
+	sectionArtists searchSection = iota
+	sectionAlbums
+	sectionSongs
+)
+
+var searchSectionNames = []string{"Artists", "Albums", "Songs"}
+
+type searchRowKind int
+
+const (
+	rowHeader searchRowKind = iota
+	rowHit
+	rowMore
+)
+
+type searchRow struct {
+	kind    searchRowKind
+	section searchSection
+	index   int
+}
+
+// SearchView shows search3 hits grouped into artists, albums and songs
+type SearchView struct {
+	app       *App
+	container *tview.Flex
+	table     *tview.Table
+	footer    *tview.TextView
+	isActive  bool
+
+	query   string
+	result  domain.SearchResult
+	hasMore [3]bool
+	loading bool
+	cancel  context.CancelFunc // cancels the search request in flight
+	rows    []searchRow
+}
+
+func NewSearchView(app *App) *SearchView {
+	sv := &SearchView{
+		app: app,
+	}
+
+	sv.table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false)
+	sv.table.SetSelectedStyle(tcell.StyleDefault.
+		Background(tcell.NewHexColor(0xffb300)).
+		Foreground(tcell.ColorWhite))
+	sv.table.SetSelectedFunc(func(row, column int) {
+		sv.open(row)
+	})
+
+	sv.footer = tview.NewTextView().
+		SetDynamicColors(true)
+
+	sv.container = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(sv.table, 0, 1, true).
+		AddItem(sv.footer, 1, 0, false)
+
+	sv.container.SetBorder(true).
+		SetBorderColor(tcell.NewHexColor(0xffb300)).
+		SetTitleColor(tcell.NewHexColor(0xffb300))
+
+	return sv
+}
+
+// Show runs a new search and displays its results
+func (sv *SearchView) Show(query string) {
+	sv.cancelSearch()
+	sv.isActive = true
+	sv.query = query
+	sv.result = domain.SearchResult{}
+	sv.hasMore = [3]bool{}
+	sv.container.SetTitle(fmt.Sprintf(" Search: %s (ESC to close) ", query))
+	sv.app.tviewApp.SetFocus(sv.table)
+	sv.render()
+	sv.search(library.SearchOptions{
+		ArtistCount: searchPageSize,
+		AlbumCount:  searchPageSize,
+		SongCount:   searchPageSize,
+	})
+}
+
+// Close hides the search view
+func (sv *SearchView) Close() {
+	sv.cancelSearch()
+	sv.isActive = false
+	sv.app.tviewApp.SetRoot(sv.app.rootFlex, true)
+	sv.app.tviewApp.SetFocus(sv.app.songTable)
+}
+
+// IsActive returns whether the search view is active
+func (sv *SearchView) IsActive() bool {
+	return sv.isActive
+}
+
+// GetContainer returns the search view container
+func (sv *SearchView) GetContainer() *tview.Flex {
+	return sv.container
+}
+
+func (sv *SearchView) setFooter(text string) {
+	sv.footer.SetText("  " + text)
+}
+
+// search fetches one page of hits and appends them to the current results.
+// Kinds with a count of 0 are left untouched.
+func (sv *SearchView) search(opts library.SearchOptions) {
+	if sv.loading {
+		return
+	}
+	sv.loading = true
+	sv.setFooter("[darkgray]Searching...")
+
+	ctx, cancel := context.WithCancel(sv.app.ctx)
+	sv.cancel = cancel
+	query := sv.query
+	go func() {
+		result, err := sv.app.library.Search(ctx, query, opts)
+		sv.app.tviewApp.QueueUpdateDraw(func() {
+			if ctx.Err() != nil {
+				// replaced by a newer search or the view was closed
+				return
+			}
+			sv.loading = false
+			cancel()
+			if err != nil {
+				sv.setFooter("[red]Search failed: " + errorText(err))
+				return
+			}
+
+			row, _ := sv.table.GetSelection()
+			if opts.ArtistCount > 0 {
+				sv.result.Artists = append(sv.result.Artists, result.Artists...)
+				sv.hasMore[sectionArtists] = len(result.Artists) == opts.ArtistCount
+			}
+			if opts.AlbumCount > 0 {
+				sv.result.Albums = append(sv.result.Albums, result.Albums...)
+				sv.hasMore[sectionAlbums] = len(result.Albums) == opts.AlbumCount
+			}
+			if opts.SongCount > 0 {
+				sv.result.Songs = append(sv.result.Songs, result.Songs...)
+				sv.hasMore[sectionSongs] = len(result.Songs) == opts.SongCount
+			}
+			sv.render()
+			sv.selectRow(row)
+		})
+	}()
+}
+
+// cancelSearch abandons the search request in flight, if any
+func (sv *SearchView) cancelSearch() {
+	if sv.cancel != nil {
+		sv.cancel()
+		sv.cancel = nil
+	}
+	sv.loading = false
+}
+
+func (sv *SearchView) loadMore(section searchSection) {
+	var opts library.SearchOptions
+	switch section {
+	case sectionArtists:
+		opts.ArtistCount, opts.ArtistOffset = searchPageSize, len(sv.result.Artists)
+	case sectionAlbums:
+		opts.AlbumCount, opts.AlbumOffset = searchPageSize, len(sv.result.Albums)
+	case sectionSongs:
+		opts.SongCount, opts.SongOffset = searchPageSize, len(sv.result.Songs)
+	}
+	sv.search(opts)
+}
+
+// selectRow selects row, or the next selectable row after it
+func (sv *SearchView) selectRow(row int) {
+	for ; row < len(sv.rows); row++ {
+		if sv.rows[row].kind != rowHeader {
+			sv.table.Select(row, 0)
+			return
+		}
+	}
+}
+
+func (sv *SearchView) render() {
+	sv.table.Clear()
+	sv.rows = nil
+
+	counts := []int{len(sv.result.Artists), len(sv.result.Albums), len(sv.result.Songs)}
+	headerStyle := tcell.StyleDefault.Foreground(tcell.NewHexColor(0xffb300)).Attributes(tcell.AttrBold)
+	rowStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	grayStyle := rowStyle.Foreground(tcell.ColorGray)
+
+	for s, name := range searchSectionNames {
+		section := searchSection(s)
+		if counts[section] == 0 {
+			continue
+		}
+
+		row := len(sv.rows)
+		sv.table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%s (%d)", name, counts[section])).
+			SetStyle(headerStyle).SetSelectable(false))
+		sv.table.SetCell(row, 1, tview.NewTableCell("").SetSelectable(false))
+		sv.table.SetCell(row, 2, tview.NewTableCell("").SetSelectable(false))
+		sv.rows = append(sv.rows, searchRow{kind: rowHeader, section: section})
+
+		for i := 0; i < counts[section]; i++ {
+			row = len(sv.rows)
+			var title, detail, extra string
+			switch section {
+			case sectionArtists:
+				artist := sv.result.Artists[i]
+				title = starMark(artist.Starred) + artist.Name
+				extra = fmt.Sprintf("%d albums", artist.AlbumCount)
+			case sectionAlbums:
+				album := sv.result.Albums[i]
+				title = starMark(album.Starred) + album.Name
+				detail = album.Artist
+				if album.Year > 0 {
+					extra = fmt.Sprintf("%d", album.Year)
+				}
+			case sectionSongs:
+				song := sv.result.Songs[i]
+				title = starMark(song.Starred) + song.Title
+				detail = song.Artist
+				extra = FormatDuration(song.Duration)
+			}
+			sv.table.SetCell(row, 0, tview.NewTableCell("  "+title).SetStyle(rowStyle).SetExpansion(1))
+			sv.table.SetCell(row, 1, tview.NewTableCell(detail).SetStyle(grayStyle).SetExpansion(1))
+			sv.table.SetCell(row, 2, tview.NewTableCell(extra).SetStyle(grayStyle).SetAlign(tview.AlignRight))
+			sv.rows = append(sv.rows, searchRow{kind: rowHit, section: section, index: i})
+		}
+
+		if sv.hasMore[section] {
+			row = len(sv.rows)
+			sv.table.SetCell(row, 0, tview.NewTableCell("  … load more "+name).SetStyle(grayStyle))
+			sv.table.SetCell(row, 1, tview.NewTableCell(""))
+			sv.table.SetCell(row, 2, tview.NewTableCell(""))
+			sv.rows = append(sv.rows, searchRow{kind: rowMore, section: section})
+		}
+	}
+
+	if len(sv.rows) == 0 {
+		if !sv.loading {
+			sv.setFooter("[darkgray]No results")
+		}
+		return
+	}
+	sv.setFooter("[darkgray]ENTER [white]open / play  [darkgray]ESC [white]close")
+}
+
+func (sv *SearchView) open(row int) {
+	if row < 0 || row >= len(sv.rows) {
+		return
+	}
+
+	r := sv.rows[row]
+	switch r.kind {
+	case rowMore:
+		sv.loadMore(r.section)
+	case rowHit:
+		switch r.section {
+		case sectionArtists:
+			sv.Close()
+			sv.app.showArtist(sv.result.Artists[r.index].ID)
+		case sectionAlbums:
+			sv.Close()
+			sv.app.showAlbum(sv.result.Albums[r.index].ID)
+		case sectionSongs:
+			sv.Close()
+			sv.app.showSearchSongs(sv.result.Songs, sv.result.Songs[r.index].ID)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/yhkl-dev/NaviCLI/domain"
+)
+
+// gatedFeatures are the client features that depend on an OpenSubsonic
+// extension, in the order the info view lists them
+var gatedFeatures = []struct {
+	extension string
+	feature   string
+}{
+	{domain.ExtSongLyrics, "Synced lyrics"},
+	{domain.ExtTranscodeOffset, "Resume transcoded streams at a position"},
+	{domain.ExtAPIKeyAuth, "API key login"},
+}
+
+// ServerView shows what the server is and which features it enables
+type ServerView struct {
+	app       *App
+	container *tview.Flex
+	text      *tview.TextView
+	footer    *tview.TextView
+	isActive  bool
+}
+
+func NewServerView(app *App) *ServerView {
+	sv := &ServerView{
+		app: app,
+	}
+
+	sv.text = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true)
+
+	sv.footer = tview.NewTextView().
+		SetDynamicColors(true)
+
+	sv.container = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(sv.text, 0, 1, true).
+		AddItem(sv.footer, 1, 0, false)
+
+	sv.container.SetBorder(true).
+		SetTitle(" Server (ESC/i to close) ").
+		SetBorderColor(tcell.NewHexColor(0xffb300)).
+		SetTitleColor(tcell.NewHexColor(0xffb300))
+
+	return sv
+}
+
+// Show displays the recorded server details and refreshes them in the
+// background
+func (sv *ServerView) Show() {
+	sv.isActive = true
+	sv.app.tviewApp.SetFocus(sv.text)
+	sv.render(sv.app.library.ServerInfo())
+	sv.setFooter("[darkgray]Refreshing...")
+
+	go func() {
+		info, err := sv.app.library.Connect(sv.app.ctx)
+		sv.app.tviewApp.QueueUpdateDraw(func() {
+			if !sv.isActive {
+				return
+			}
+			if err != nil {
+				sv.setFooter("[red]Refresh failed: " + errorText(err))
+				return
+			}
+			sv.render(info)
+			sv.setFooter("")
+		})
+	}()
+}
+
+// Close hides the server view
+func (sv *ServerView) Close() {
+	sv.isActive = false
+	sv.app.tviewApp.SetRoot(sv.app.rootFlex, true)
+	sv.app.tviewApp.SetFocus(sv.app.songTable)
+}
+
+// IsActive returns whether the server view is active
+func (sv *ServerView) IsActive() bool {
+	return sv.isActive
+}
+
+// GetContainer returns the server view container
+func (sv *ServerView) GetContainer() *tview.Flex {
+	return sv.container
+}
+
+func (sv *ServerView) setFooter(text string) {
+	sv.footer.SetText("  " + text)
+}
+
+func (sv *ServerView) render(info *domain.ServerInfo) {
+	var b strings.Builder
+	row := func(label, value string) {
+		fmt.Fprintf(&b, "  [darkgray]%-14s[white]%s\n", label, value)
+	}
+
+	connection := "[darkgray]● disconnected"
+	if sv.app.serverConnected.Load() {
+		connection = "[green]● connected"
+	}
+	row("Server", tview.Escape(sv.app.cfg.Server.URL))
+	row("User", tview.Escape(sv.app.cfg.Server.Username))
+	row("Connection", connection)
+
+	if info == nil {
+		b.WriteString("\n  [darkgray]No server details yet\n")
+		sv.text.SetText(b.String())
+		return
+	}
+
+	software := "Subsonic-compatible server"
+	if info.Type != "" {
+		software = strings.TrimSpace(info.Type + " " + info.ServerVersion)
+	}
+	openSubsonic := "no"
+	if info.OpenSubsonic {
+		openSubsonic = "yes"
+	}
+	row("Software", tview.Escape(software))
+	row("API version", fmt.Sprintf("%s [darkgray](client %s)", info.APIVersion, sv.app.cfg.Client.APIVersion))
+	row("OpenSubsonic", openSubsonic)
+	profile, _ := sv.app.cfg.Player.TranscodeProfile(sv.app.profileName())
+	row("Streaming", fmt.Sprintf("%s [darkgray](%s)", sv.app.profileName(), describeProfile(profile)))
+
+	b.WriteString("\n  [#ffb300]Features\n")
+	for _, f := range gatedFeatures {
+		mark := "[darkgray]✗"
+		if info.Supports(f.extension) {
+			mark = "[green]✓"
+		}
+		fmt.Fprintf(&b, "  %s [white]%-40s[darkgray]%s\n", mark, f.feature, f.extension)
+	}
+
+	if len(info.Extensions) > 0 {
+		b.WriteString("\n  [#ffb300]Extensions\n")
+		names := make([]string, 0, len(info.Extensions))
+		for name := range info.Extensions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			versions := make([]string, len(info.Extensions[name]))
+			for i, v := range info.Extensions[name] {
+				versions[i] = fmt.Sprintf("v%d", v)
+			}
+			fmt.Fprintf(&b, "  [white]%-30s[darkgray]%s\n", tview.Escape(name), strings.Join(versions, ", "))
+		}
+	}
+
+	sv.text.SetText(b.String())
+	sv.text.ScrollToBeginning()
+}
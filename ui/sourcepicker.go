@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// SourceView lets the user pick where the song list is loaded from
+type SourceView struct {
+	app       *App
+	container *tview.Flex
+	table     *tview.Table
+	input     *tview.InputField
+	footer    *tview.TextView
+	isActive  bool
+}
+
+func NewSourceView(app *App) *SourceView {
+	sv := &SourceView{
+		app: app,
+	}
+
+	sv.table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false)
+	sv.table.SetSelectedStyle(tcell.StyleDefault.
+		Background(tcell.NewHexColor(0xffb300)).
+		Foreground(tcell.ColorWhite))
+	sv.table.SetSelectedFunc(func(row, column int) {
+		sv.choose(row)
+	})
+
+	sv.input = tview.NewInputField().
+		SetLabel("[#ffb300]Years (from-to): ").
+		SetFieldBackgroundColor(tcell.ColorDefault)
+	sv.input.SetDoneFunc(sv.yearsDone)
+
+	sv.footer = tview.NewTextView().
+		SetDynamicColors(true)
+
+	sv.container = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(sv.table, 0, 1, true).
+		AddItem(sv.input, 0, 0, false).
+		AddItem(sv.footer, 1, 0, false)
+
+	sv.container.SetBorder(true).
+		SetTitle(" Song Source (ESC to close) ").
+		SetBorderColor(tcell.NewHexColor(0xffb300)).
+		SetTitleColor(tcell.NewHexColor(0xffb300))
+
+	return sv
+}
+
+// Show displays the source picker with the current source selected
+func (sv *SourceView) Show() {
+	sv.isActive = true
+	sv.render()
+	sv.app.songsMu.RLock()
+	sv.table.Select(sv.app.songSource, 0)
+	sv.app.songsMu.RUnlock()
+	sv.footer.SetText("  [darkgray]ENTER [white]load source  [darkgray]ESC [white]close")
+	sv.app.tviewApp.SetFocus(sv.table)
+}
+
+// Close hides the source picker
+func (sv *SourceView) Close() {
+	sv.isActive = false
+	sv.container.ResizeItem(sv.input, 0, 0)
+	sv.app.tviewApp.SetRoot(sv.app.rootFlex, true)
+	sv.app.tviewApp.SetFocus(sv.app.songTable)
+}
+
+// IsActive returns whether the source picker is active
+func (sv *SourceView) IsActive() bool {
+	return sv.isActive
+}
+
+// GetContainer returns the source picker container
+func (sv *SourceView) GetContainer() *tview.Flex {
+	return sv.container
+}
+
+func (sv *SourceView) render() {
+	sv.table.Clear()
+	sv.app.songsMu.RLock()
+	current := sv.app.songSource
+	sv.app.songsMu.RUnlock()
+
+	for i, src := range songSources {
+		marker := "  "
+		color := tcell.ColorWhite
+		if i == current {
+			marker = "▶ "
+			color = tcell.ColorLightGreen
+		}
+		hint := ""
+		switch src.input {
+		case inputYears:
+			hint = "[asks for a year range]"
+		case inputGenre:
+			hint = "[opens the genre picker]"
+		}
+		sv.table.SetCell(i, 0, tview.NewTableCell(marker+src.name).SetTextColor(color).SetExpansion(1))
+		sv.table.SetCell(i, 1, tview.NewTableCell(hint).SetTextColor(tcell.ColorGray))
+	}
+}
+
+func (sv *SourceView) choose(index int) {
+	if index < 0 || index >= len(songSources) {
+		return
+	}
+	switch songSources[index].input {
+	case inputYears:
+		sv.app.songsMu.RLock()
+		from, to := sv.app.fromYear, sv.app.toYear
+		sv.app.songsMu.RUnlock()
+		text := ""
+		if from > 0 {
+			text = fmt.Sprintf("%d-%d", from, to)
+		}
+		sv.input.SetText(text)
+		sv.container.ResizeItem(sv.input, 1, 0)
+		sv.footer.SetText("  [darkgray]e.g. 1990-1999 or 1994, ENTER [white]load  [darkgray]ESC [white]cancel")
+		sv.app.tviewApp.SetFocus(sv.input)
+	case inputGenre:
+		sv.Close()
+		sv.app.showGenres()
+	default:
+		sv.Close()
+		sv.app.selectSource(index)
+	}
+}
+
+func (sv *SourceView) yearsDone(key tcell.Key) {
+	if key != tcell.KeyEnter {
+		sv.container.ResizeItem(sv.input, 0, 0)
+		sv.app.tviewApp.SetFocus(sv.table)
+		return
+	}
+	from, to, err := parseYearRange(sv.input.GetText())
+	if err != nil {
+		sv.footer.SetText("  [red]" + err.Error())
+		return
+	}
+	sv.Close()
+	sv.app.selectYears(from, to)
+}
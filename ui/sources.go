@@ -0,0 +1,175 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/yhkl-dev/NaviCLI/config"
+	"github.com/yhkl-dev/NaviCLI/domain"
+	"github.com/yhkl-dev/NaviCLI/library"
+)
+
+// sourceInput says what the source picker has to ask for before a source
+// can load
+type sourceInput int
+
+const (
+	inputNone sourceInput = iota
+	inputYears
+	inputGenre
+)
+
+type songSource struct {
+	name  string
+	input sourceInput
+	load  func(ctx context.Context, a *App) ([]domain.Song, error)
+}
+
+var songSources = []songSource{
+	{name: "Random", load: func(ctx context.Context, a *App) ([]domain.Song, error) {
+		return a.withMinRating(a.library.GetRandomSongs(ctx, a.cfg.UI.FetchSize))
+	}},
+	{name: "Albums", load: func(ctx context.Context, a *App) ([]domain.Song, error) {
+		return a.withMinRating(a.library.GetAlbumSongs(ctx, "alphabeticalByName", library.AlbumListOptions{}))
+	}},
+	albumListSource("Albums by Artist", "alphabeticalByArtist", inputNone),
+	albumListSource("Newest", "newest", inputNone),
+	albumListSource("Recently Played", "recent", inputNone),
+	albumListSource("Most Played", "frequent", inputNone),
+	albumListSource("Highest Rated", "highest", inputNone),
+	albumListSource("Random Albums", "random", inputNone),
+	{name: "Starred", load: func(ctx context.Context, a *App) ([]domain.Song, error) { return a.library.GetStarredSongs(ctx) }},
+	albumListSource("Starred Albums", "starred", inputNone),
+	albumListSource("By Year", "byYear", inputYears),
+	{name: "By Genre", input: inputGenre, load: func(ctx context.Context, a *App) ([]domain.Song, error) {
+		a.songsMu.RLock()
+		genre := a.genre
+		a.songsMu.RUnlock()
+		return a.library.GetSongsByGenre(ctx, genre, a.cfg.UI.FetchSize)
+	}},
+	{name: "Radio", load: loadStationSongs},
+}
+
+// albumListSource loads the songs of the albums in a getAlbumList2 list type,
+// capped at the configured fetch size
+func albumListSource(name, listType string, input sourceInput) songSource {
+	return songSource{name: name, input: input, load: func(ctx context.Context, a *App) ([]domain.Song, error) {
+		a.songsMu.RLock()
+		opts := library.AlbumListOptions{
+			FromYear: a.fromYear,
+			ToYear:   a.toYear,
+			Genre:    a.genre,
+			Limit:    a.cfg.UI.FetchSize,
+		}
+		a.songsMu.RUnlock()
+		return a.withMinRating(a.library.GetAlbumSongs(ctx, listType, opts))
+	}}
+}
+
+func sourceIndex(name string) int {
+	for i, src := range songSources {
+		if src.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// sourceName returns the title bar label of the current source. The caller
+// must hold songsMu.
+func (a *App) sourceName() string {
+	if a.sourceLabel != "" {
+		return a.sourceLabel
+	}
+	src := songSources[a.songSource]
+	switch src.input {
+	case inputGenre:
+		return "Genre: " + a.genre
+	case inputYears:
+		return fmt.Sprintf("Years %d–%d", a.fromYear, a.toYear)
+	}
+	return src.name
+}
+
+// selectSource switches to songSources[index], reloads the song list and
+// remembers the choice for the next launch
+func (a *App) selectSource(index int) {
+	a.songsMu.Lock()
+	a.songSource = index
+	a.sourceLabel = ""
+	a.songsMu.Unlock()
+	a.saveSourceState()
+	go a.loadMusic()
+	a.updateSortTitle()
+}
+
+// selectGenre switches the song list to the given genre
+func (a *App) selectGenre(genre string) {
+	a.songsMu.Lock()
+	a.genre = genre
+	a.songsMu.Unlock()
+	a.selectSource(sourceIndex("By Genre"))
+}
+
+// selectYears switches the song list to albums released in a year range
+func (a *App) selectYears(from, to int) {
+	a.songsMu.Lock()
+	a.fromYear, a.toYear = from, to
+	a.songsMu.Unlock()
+	a.selectSource(sourceIndex("By Year"))
+}
+
+// restoreSource applies the source saved by a previous session
+func (a *App) restoreSource() {
+	if a.uiState == nil {
+		a.uiState = &config.State{}
+		return
+	}
+	index := sourceIndex(a.uiState.Source)
+	if index < 0 {
+		return
+	}
+	src := songSources[index]
+	if src.input == inputGenre && a.uiState.Genre == "" {
+		return
+	}
+	if src.input == inputYears && a.uiState.FromYear == 0 {
+		return
+	}
+	a.songSource = index
+	a.genre = a.uiState.Genre
+	a.fromYear = a.uiState.FromYear
+	a.toYear = a.uiState.ToYear
+}
+
+func (a *App) saveSourceState() {
+	a.songsMu.RLock()
+	a.uiState.Source = songSources[a.songSource].name
+	a.uiState.Genre = a.genre
+	a.uiState.FromYear = a.fromYear
+	a.uiState.ToYear = a.toYear
+	a.songsMu.RUnlock()
+	if err := a.uiState.Save(); err != nil {
+		log.Printf("Failed to save state: %v", err)
+	}
+}
+
+// parseYearRange accepts "1990-1999" or a single year such as "1994"
+func parseYearRange(text string) (from, to int, err error) {
+	fromText, toText, found := strings.Cut(strings.TrimSpace(text), "-")
+	if !found {
+		toText = fromText
+	}
+	from, err = strconv.Atoi(strings.TrimSpace(fromText))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid year %q", fromText)
+	}
+	to, err = strconv.Atoi(strings.TrimSpace(toText))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid year %q", toText)
+	}
+	return from, to, nil
+}
@@ -0,0 +1,324 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/yhkl-dev/NaviCLI/domain"
+)
+
+// stationSong wraps a radio station as a song so it can sit in the song list
+// and go through the regular play path
+func stationSong(station domain.RadioStation) domain.Song {
+	return domain.Song{
+		ID:        station.ID,
+		Title:     station.Name,
+		Artist:    "Internet Radio",
+		Album:     station.HomePageURL,
+		StreamURL: station.StreamURL,
+	}
+}
+
+func loadStationSongs(ctx context.Context, a *App) ([]domain.Song, error) {
+	stations, err := a.library.GetRadioStations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	songs := make([]domain.Song, len(stations))
+	for i, station := range stations {
+		songs[i] = stationSong(station)
+	}
+	return songs, nil
+}
+
+// refreshStreamTitle reads the ICY title after mpv reports new metadata
+func (a *App) refreshStreamTitle() {
+	title, err := a.player.StreamTitle()
+	if err != nil {
+		return
+	}
+	a.streamTitle.Store(title)
+}
+
+// updateStreamDisplay shows the live stream title in place of the progress
+// bar, which has no meaning for an endless stream
+func (a *App) updateStreamDisplay(song *domain.Song) {
+	title, _ := a.streamTitle.Load().(string)
+	if title == "" {
+		title = "[darkgray]waiting for stream title..."
+	} else {
+		title = "[white]" + tview.Escape(title)
+	}
+
+	volumeText := "??"
+	volumeVal := 0.0
+	if vol, err := a.player.GetVolume(); err == nil {
+		volumeText = fmt.Sprintf("%.0f%%", vol)
+		volumeVal = vol
+	}
+
+	spinner := SpinnerChar(int(a.tickCount.Load()))
+	volBar := CreateVolumeBar(volumeVal, 10)
+	bottomBar := fmt.Sprintf("\n  [#ffb300]● LIVE  %s\n  [darkgray]%s    Vol: [white]%s\n  %s [#ffb300]▶ STREAMING\n",
+		title, tview.Escape(song.StreamURL), volumeText, spinner)
+	playingStatus := "[#ffb300]▶ STREAMING"
+	extras := "  [gray]Now: " + title + "\n"
+
+	a.tviewApp.QueueUpdateDraw(func() {
+		if a.progressBar != nil {
+			a.progressBar.SetText(bottomBar)
+		}
+		if a.statusBar != nil {
+			a.statusBar.SetText(FormatSongInfo(*song, playingStatus, spinner, volBar, a.leftPanelTextWidth(), a.connectionStatus(), extras))
+		}
+	})
+}
+
+// StationsView lists the server's internet radio stations and edits them
+type StationsView struct {
+	app       *App
+	container *tview.Flex
+	table     *tview.Table
+	input     *tview.InputField
+	footer    *tview.TextView
+	isActive  bool
+
+	stations      []domain.RadioStation
+	confirmDelete bool
+}
+
+func NewStationsView(app *App) *StationsView {
+	sv := &StationsView{
+		app: app,
+	}
+
+	sv.table = tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+	sv.table.SetSelectedStyle(tcell.StyleDefault.
+		Background(tcell.NewHexColor(0xffb300)).
+		Foreground(tcell.ColorWhite))
+	sv.table.SetInputCapture(sv.handleKey)
+
+	sv.input = tview.NewInputField().
+		SetFieldBackgroundColor(tcell.ColorDefault)
+
+	sv.footer = tview.NewTextView().
+		SetDynamicColors(true)
+
+	sv.container = tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(sv.table, 0, 1, true).
+		AddItem(sv.input, 0, 0, false).
+		AddItem(sv.footer, 1, 0, false)
+
+	sv.container.SetBorder(true).
+		SetTitle(" Radio Stations (ESC to close) ").
+		SetBorderColor(tcell.NewHexColor(0xffb300)).
+		SetTitleColor(tcell.NewHexColor(0xffb300))
+
+	return sv
+}
+
+// Show displays the station list
+func (sv *StationsView) Show() {
+	sv.isActive = true
+	sv.confirmDelete = false
+	sv.app.tviewApp.SetFocus(sv.table)
+	sv.load()
+}
+
+// Close hides the station list
+func (sv *StationsView) Close() {
+	sv.isActive = false
+	sv.hideInput()
+	sv.app.tviewApp.SetRoot(sv.app.rootFlex, true)
+	sv.app.tviewApp.SetFocus(sv.app.songTable)
+}
+
+// IsActive returns whether the station list is active
+func (sv *StationsView) IsActive() bool {
+	return sv.isActive
+}
+
+// GetContainer returns the station list container
+func (sv *StationsView) GetContainer() *tview.Flex {
+	return sv.container
+}
+
+func (sv *StationsView) setFooter(text string) {
+	sv.footer.SetText("  " + text)
+}
+
+func (sv *StationsView) showKeys() {
+	sv.setFooter("[darkgray]ENTER [white]play  [darkgray]n [white]new  [darkgray]e [white]edit  [darkgray]d [white]delete")
+}
+
+func (sv *StationsView) load() {
+	sv.setFooter("[darkgray]Loading stations...")
+	go func() {
+		stations, err := sv.app.library.GetRadioStations(sv.app.ctx)
+		sv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				sv.setFooter("[red]Loading stations failed: " + errorText(err))
+				return
+			}
+			sv.stations = stations
+			sv.render()
+			sv.showKeys()
+		})
+	}()
+}
+
+func (sv *StationsView) render() {
+	for i := sv.table.GetRowCount() - 1; i >= 0; i-- {
+		sv.table.RemoveRow(i)
+	}
+	headerStyle := tcell.StyleDefault.Foreground(tcell.NewHexColor(0xffb300)).Attributes(tcell.AttrBold)
+	sv.table.SetCell(0, 0, tview.NewTableCell("Name").SetStyle(headerStyle))
+	sv.table.SetCell(0, 1, tview.NewTableCell("Stream").SetStyle(headerStyle).SetExpansion(1))
+
+	rowStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	for i, station := range sv.stations {
+		sv.table.SetCell(i+1, 0, tview.NewTableCell(station.Name).SetStyle(rowStyle).SetMaxWidth(30))
+		sv.table.SetCell(i+1, 1, tview.NewTableCell(station.StreamURL).
+			SetStyle(rowStyle.Foreground(tcell.ColorGray)).SetExpansion(1))
+	}
+	if len(sv.stations) == 0 {
+		sv.table.SetCell(1, 0, tview.NewTableCell("No stations").SetTextColor(tcell.ColorGray))
+	}
+	sv.table.Select(1, 0)
+}
+
+func (sv *StationsView) selected() (int, *domain.RadioStation) {
+	row, _ := sv.table.GetSelection()
+	if row <= 0 || row > len(sv.stations) {
+		return row, nil
+	}
+	return row, &sv.stations[row-1]
+}
+
+func (sv *StationsView) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	if sv.confirmDelete {
+		sv.confirmDelete = false
+		if event.Rune() == 'y' || event.Rune() == 'Y' {
+			sv.deleteSelected()
+		} else {
+			sv.showKeys()
+		}
+		return nil
+	}
+
+	switch event.Key() {
+	case tcell.KeyEscape:
+		sv.Close()
+		return nil
+	case tcell.KeyEnter:
+		sv.play()
+		return nil
+	}
+
+	switch event.Rune() {
+	case 'n':
+		sv.edit(domain.RadioStation{})
+	case 'e':
+		if _, station := sv.selected(); station != nil {
+			sv.edit(*station)
+		}
+	case 'd':
+		if _, station := sv.selected(); station != nil {
+			sv.confirmDelete = true
+			sv.setFooter(fmt.Sprintf("[red]Delete %q? [white]y[darkgray] to confirm, any other key to cancel", station.Name))
+		}
+	default:
+		return event
+	}
+	return nil
+}
+
+// play loads every station into the song list and plays the selected one
+func (sv *StationsView) play() {
+	row, station := sv.selected()
+	if station == nil {
+		return
+	}
+	songs := make([]domain.Song, len(sv.stations))
+	for i, st := range sv.stations {
+		songs[i] = stationSong(st)
+	}
+	sv.Close()
+	sv.app.setSongs(songs, "Radio Stations")
+	sv.app.goToSongPage(row - 1)
+	go sv.app.playSongAtIndex(row - 1)
+}
+
+// edit asks for a name and then a stream URL. A station without an ID is
+// created, otherwise it is updated.
+func (sv *StationsView) edit(station domain.RadioStation) {
+	sv.prompt("Name: ", station.Name, func(name string) {
+		station.Name = name
+		sv.prompt("Stream URL: ", station.StreamURL, func(streamURL string) {
+			station.StreamURL = streamURL
+			sv.save(station)
+		})
+	})
+}
+
+func (sv *StationsView) prompt(label, initial string, done func(text string)) {
+	sv.input.SetLabel("[#ffb300]" + label).SetText(initial)
+	sv.input.SetDoneFunc(func(key tcell.Key) {
+		text := strings.TrimSpace(sv.input.GetText())
+		sv.hideInput()
+		sv.app.tviewApp.SetFocus(sv.table)
+		if key == tcell.KeyEnter && text != "" {
+			done(text)
+		}
+	})
+	sv.container.ResizeItem(sv.input, 1, 0)
+	sv.app.tviewApp.SetFocus(sv.input)
+}
+
+func (sv *StationsView) hideInput() {
+	sv.container.ResizeItem(sv.input, 0, 0)
+}
+
+func (sv *StationsView) save(station domain.RadioStation) {
+	sv.setFooter("[darkgray]Saving station...")
+	go func() {
+		var err error
+		if station.ID == "" {
+			err = sv.app.library.CreateRadioStation(sv.app.ctx, station)
+		} else {
+			err = sv.app.library.UpdateRadioStation(sv.app.ctx, station)
+		}
+		sv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				sv.setFooter("[red]Saving station failed: " + errorText(err))
+				return
+			}
+			sv.load()
+		})
+	}()
+}
+
+func (sv *StationsView) deleteSelected() {
+	_, station := sv.selected()
+	if station == nil {
+		return
+	}
+	id := station.ID
+	go func() {
+		err := sv.app.library.DeleteRadioStation(sv.app.ctx, id)
+		sv.app.tviewApp.QueueUpdateDraw(func() {
+			if err != nil {
+				sv.setFooter("[red]Deleting station failed: " + errorText(err))
+				return
+			}
+			sv.load()
+		})
+	}()
+}
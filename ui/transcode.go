@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/yhkl-dev/NaviCLI/config"
+	"github.com/yhkl-dev/NaviCLI/domain"
+	"github.com/yhkl-dev/NaviCLI/library"
+)
+
+// restoreProfile picks the transcoding profile saved in the state file, or
+// the one named in the config
+func (a *App) restoreProfile() {
+	name := a.cfg.Player.Profile
+	if saved := a.uiState.Profile; saved != "" {
+		name = saved
+	}
+	if _, ok := a.cfg.Player.TranscodeProfile(name); !ok {
+		log.Printf("Unknown transcoding profile %q, using %s", name, config.DefaultProfile)
+		name = ""
+	}
+	if name == "" {
+		name = config.DefaultProfile
+	}
+	a.profile.Store(name)
+}
+
+func (a *App) profileName() string {
+	name, _ := a.profile.Load().(string)
+	return name
+}
+
+func (a *App) streamOptions() library.StreamOptions {
+	profile, _ := a.cfg.Player.TranscodeProfile(a.profileName())
+	return library.StreamOptions{
+		Format:     profile.Format,
+		MaxBitRate: profile.MaxBitRate,
+	}
+}
+
+// cycleProfile switches to the next transcoding profile. The playing track
+// keeps its stream; the new profile applies from the next track on.
+func (a *App) cycleProfile() {
+	names := a.cfg.Player.ProfileNames()
+	next := names[0]
+	for i, name := range names {
+		if name == a.profileName() {
+			next = names[(i+1)%len(names)]
+			break
+		}
+	}
+	a.profile.Store(next)
+
+	a.uiState.Profile = next
+	if err := a.uiState.Save(); err != nil {
+		log.Printf("Failed to save state: %v", err)
+	}
+
+	profile, _ := a.cfg.Player.TranscodeProfile(next)
+	a.statusBar.SetText(fmt.Sprintf("[#ffb300]Profile: %s [darkgray](%s, applies from the next track)",
+		next, describeProfile(profile)))
+}
+
+// playingStreamInfo returns the stream label of the playing track
+func (a *App) playingStreamInfo() string {
+	info, _ := a.streamInfo.Load().(string)
+	return info
+}
+
+// describeProfile summarises a profile for the status bar
+func describeProfile(p config.TranscodeProfile) string {
+	var parts []string
+	switch p.Format {
+	case "":
+		parts = append(parts, "server default format")
+	case "raw":
+		parts = append(parts, "original files")
+	default:
+		parts = append(parts, strings.ToUpper(p.Format))
+	}
+	if p.MaxBitRate > 0 && p.Format != "raw" {
+		parts = append(parts, fmt.Sprintf("max %dkbps", p.MaxBitRate))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// transcoded reports whether the server converts track under opts. A song
+// already in the requested format and within the bitrate cap is passed
+// through untouched.
+func transcoded(track domain.Song, opts library.StreamOptions) bool {
+	if track.StreamURL != "" || opts.Format == "raw" {
+		return false
+	}
+	format := opts.Format
+	if format == "" {
+		format = track.Suffix
+	}
+	converted := !strings.EqualFold(format, track.Suffix)
+	capped := opts.MaxBitRate > 0 && track.BitRate > opts.MaxBitRate
+	return converted || capped
+}
+
+// canStartTranscodeAt reports whether track should be resumed by having the
+// server start the transcoded stream at the position, which needs the
+// transcodeOffset extension
+func (a *App) canStartTranscodeAt(track domain.Song, opts library.StreamOptions) bool {
+	return transcoded(track, opts) && a.library.ServerInfo().Supports(domain.ExtTranscodeOffset)
+}
+
+// streamLabel tells whether track is streamed as the original file or
+// transcoded under opts
+func streamLabel(track domain.Song, opts library.StreamOptions) string {
+	if !transcoded(track, opts) {
+		return "[green]◆ Original"
+	}
+	format := opts.Format
+	if format == "" {
+		format = track.Suffix
+	}
+	target := strings.ToUpper(format)
+	if opts.MaxBitRate > 0 {
+		target += fmt.Sprintf(" · ≤%dkbps", opts.MaxBitRate)
+	}
+	return "[#ffb300]⇄ Transcoded → " + target
+}